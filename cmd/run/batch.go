@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/abakunov/mazes/internal/analysis"
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+// errInvalidSize is returned for sizes not written as WIDTHxHEIGHT.
+var errInvalidSize = errors.New("size must be written as WIDTHxHEIGHT")
+
+// errEvenSize is returned for sizes with an even width or height.
+var errEvenSize = errors.New("width and height must be odd")
+
+// batchOptions holds the flags of the batch command.
+type batchOptions struct {
+	count       int
+	workers     int
+	sizes       []domain.Point
+	generators  []string
+	seed        int64
+	outDir      string
+	minLength   int
+	maxLength   int
+	minDeadEnds int
+	minScore    float64
+	maxScore    float64
+}
+
+// runBatch generates, solves and filters many mazes concurrently, writing the accepted ones
+// to the output directory in the binary format together with a manifest.
+func runBatch(args []string) error {
+	opts, err := parseBatchOptions(args)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+		return err
+	}
+
+	analyzer := analysis.NewAnalyzer()
+
+	// The filter and the handler see the same results, so the metrics are computed once
+	var (
+		mu      sync.Mutex
+		metrics = make(map[int]analysis.Metrics)
+	)
+
+	batch := application.NewBatchGenerator(opts.workers)
+	batch.Accept = func(result *application.BatchResult) bool {
+		m := analyzer.AnalyzePath(result.Maze, result.Entry, result.Exit, result.Path)
+
+		mu.Lock()
+		metrics[result.Job.Index] = m
+		mu.Unlock()
+
+		return opts.accepts(m)
+	}
+
+	var entries []infrastructure.ManifestEntry
+
+	err = runCancelable(func(ctx context.Context) error {
+		return batch.Run(ctx, opts.jobs(), func(result *application.BatchResult) error {
+			name := fmt.Sprintf("maze-%05d.maze", result.Job.Index)
+			if err := writeBinaryMaze(filepath.Join(opts.outDir, name), result); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			m := metrics[result.Job.Index]
+			mu.Unlock()
+
+			entries = append(entries, manifestEntry(name, result, m))
+
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeManifest(filepath.Join(opts.outDir, "manifest.json"), opts.count, entries); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated %d mazes, %d accepted, written to %s\n", opts.count, len(entries), opts.outDir)
+
+	return nil
+}
+
+// jobs returns the jobs of the batch: the sizes alternate from one maze to the next and the
+// generators from one round of sizes to the next.
+func (opts *batchOptions) jobs() []application.BatchJob {
+	jobs := make([]application.BatchJob, opts.count)
+	for i := range jobs {
+		size := opts.sizes[i%len(opts.sizes)]
+		jobs[i] = application.BatchJob{
+			Index:     i,
+			Seed:      opts.seed + int64(i),
+			Width:     size.X,
+			Height:    size.Y,
+			Generator: opts.generators[(i/len(opts.sizes))%len(opts.generators)],
+		}
+	}
+
+	return jobs
+}
+
+// accepts checks the metrics of a maze against the filter flags.
+func (opts *batchOptions) accepts(m analysis.Metrics) bool {
+	return m.SolutionLength >= opts.minLength && (opts.maxLength == 0 || m.SolutionLength <= opts.maxLength) &&
+		m.DeadEnds >= opts.minDeadEnds && m.Difficulty >= opts.minScore && (opts.maxScore == 0 || m.Difficulty <= opts.maxScore)
+}
+
+// manifestEntry describes an accepted maze of the batch saved to the named file.
+func manifestEntry(name string, result *application.BatchResult, m analysis.Metrics) infrastructure.ManifestEntry {
+	return infrastructure.ManifestEntry{
+		File:       name,
+		Index:      result.Job.Index,
+		Seed:       result.Job.Seed,
+		Generator:  result.Job.Generator,
+		Width:      result.Job.Width,
+		Height:     result.Job.Height,
+		Entry:      result.Entry,
+		Exit:       result.Exit,
+		PathLength: len(result.Path),
+		Metrics:    m,
+	}
+}
+
+// writeManifest saves the manifest of the accepted mazes.
+func writeManifest(path string, generated int, entries []infrastructure.ManifestEntry) error {
+	manifest, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer manifest.Close()
+
+	if err := infrastructure.NewManifestExporter().Export(manifest, generated, entries); err != nil {
+		return err
+	}
+
+	return manifest.Close()
+}
+
+// parseBatchOptions reads the flags of the batch command.
+func parseBatchOptions(args []string) (*batchOptions, error) {
+	opts := &batchOptions{}
+
+	var sizes, generators string
+
+	flags := flag.NewFlagSet("batch", flag.ContinueOnError)
+	flags.IntVar(&opts.count, "n", 100, "number of mazes to generate")
+	flags.IntVar(&opts.workers, "workers", 0, "number of mazes generated at once (0 - one per CPU)")
+	flags.StringVar(&sizes, "sizes", "21x21", "comma-separated maze sizes, WIDTHxHEIGHT, both odd")
+	flags.StringVar(&generators, "generators", "dfs,kruskal", "comma-separated generators: dfs, kruskal, parallel")
+	flags.Int64Var(&opts.seed, "seed", 1, "seed of the first maze, the following mazes use the next seeds")
+	flags.StringVar(&opts.outDir, "out", "mazes", "output directory")
+	flags.IntVar(&opts.minLength, "min-length", 0, "minimum solution length")
+	flags.IntVar(&opts.maxLength, "max-length", 0, "maximum solution length (0 - unlimited)")
+	flags.IntVar(&opts.minDeadEnds, "min-dead-ends", 0, "minimum number of dead ends")
+	flags.Float64Var(&opts.minScore, "min-difficulty", 0, "minimum difficulty score (0-100)")
+	flags.Float64Var(&opts.maxScore, "max-difficulty", 0, "maximum difficulty score (0 - unlimited)")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if opts.count <= 0 {
+		return nil, fmt.Errorf("number of mazes must be positive, got %d", opts.count)
+	}
+
+	for _, size := range strings.Split(sizes, ",") {
+		width, height, found := strings.Cut(strings.TrimSpace(size), "x")
+		w, errW := strconv.Atoi(width)
+		h, errH := strconv.Atoi(height)
+
+		if !found || errW != nil || errH != nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidSize, size)
+		}
+
+		if w < domain.MinMazeSize || h < domain.MinMazeSize {
+			return nil, fmt.Errorf("size %q: %w", size, &domain.DimensionsError{Width: w, Height: h})
+		}
+
+		// As in the interactive mode, only odd sizes keep every generator on the cell lattice
+		if w%2 == 0 || h%2 == 0 {
+			return nil, fmt.Errorf("%w: %q", errEvenSize, size)
+		}
+
+		opts.sizes = append(opts.sizes, domain.Point{X: w, Y: h})
+	}
+
+	for _, name := range strings.Split(generators, ",") {
+		name = strings.TrimSpace(name)
+		if _, err := application.NewSeededGenerator(name, nil); err != nil {
+			return nil, fmt.Errorf("%w: %q", err, name)
+		}
+
+		opts.generators = append(opts.generators, name)
+	}
+
+	return opts, nil
+}
+
+// writeBinaryMaze saves a maze of the batch in the compressed binary format.
+func writeBinaryMaze(path string, result *application.BatchResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer, err := infrastructure.NewBinaryWriter(file, infrastructure.MazeHeader{
+		Width:      result.Maze.Width,
+		Height:     result.Maze.Height,
+		Seed:       result.Job.Seed,
+		Entry:      result.Entry,
+		Exit:       result.Exit,
+		Compressed: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writer.WriteMaze(result.Maze); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -1,28 +1,75 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 
+	"github.com/abakunov/mazes/internal/analysis"
 	"github.com/abakunov/mazes/internal/application"
 	"github.com/abakunov/mazes/internal/domain"
 	"github.com/abakunov/mazes/internal/infrastructure"
 )
 
+// interruptCtx is canceled by the first Ctrl+C or SIGTERM; the long work of the program derives its
+// context from it. Calling stopInterrupts restores the default handling of the signals.
+var interruptCtx, stopInterrupts = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+// runningWork counts the work started by runCancelable that has not finished yet.
+var runningWork atomic.Int32
+
+// terminateOnce makes sure the interruption is reported a single time.
+var terminateOnce sync.Once
+
 func main() {
-	// Setting up graceful shutdown
-	exitChan := make(chan os.Signal, 1)
-	signal.Notify(exitChan, os.Interrupt, syscall.SIGTERM)
+	// A second Ctrl+C or SIGTERM kills the program even if the running work does not stop
+	go func() {
+		<-interruptCtx.Done()
+		stopInterrupts()
+	}()
+
+	done := make(chan struct{})
 
-	// Launch a goroutine to handle the shutdown signal
 	go func() {
-		<-exitChan
-		fmt.Println("\nProgram terminated by the user.")
-		os.Exit(0)
+		defer close(done)
+
+		run()
 	}()
 
+	select {
+	case <-done:
+	case <-interruptCtx.Done():
+		// Running work reports the interruption itself once it stops, a prompt cannot be interrupted
+		if runningWork.Load() == 0 {
+			terminate()
+		}
+
+		<-done
+	}
+}
+
+// run runs the batch command or the interactive mode.
+func run() {
+	// Non-interactive batch mode: run batch [flags]
+	if len(os.Args) > 1 && os.Args[1] == "batch" {
+		if err := runBatch(os.Args[2:]); err != nil {
+			exitOnError(err)
+		}
+
+		return
+	}
+
+	runInteractive()
+}
+
+// runInteractive asks for the maze parameters, then generates, solves and renders the maze.
+func runInteractive() {
 	// Get input data from the user through separate functions
 	width := infrastructure.GetWidth()
 	height := infrastructure.GetHeight()
@@ -30,54 +77,268 @@ func main() {
 	// Maze initialization
 	maze := domain.NewMaze(width, height)
 
-	// Select generation algorithm
-	algorithmChoice := infrastructure.GetAlgorithmChoice()
+	// Select generation algorithm and define the maze generator corresponding to the ContextGenerator interface
+	generator := newGenerator(infrastructure.GetAlgorithmChoice())
+
+	// Select the method for entering start and exit points
+	entryExitChoice := infrastructure.GetEntryExitChoice()
+	entryPoint, exitPoint := infrastructure.GetEntryExitPoints(entryExitChoice, width, height)
+
+	targetDifficulty := 0
+	if entryExitChoice == 4 {
+		targetDifficulty = infrastructure.GetTargetDifficulty()
+	}
+
+	// Maze generation
+	err := runCancelable(func(ctx context.Context) error {
+		return generator.GenerateContext(ctx, maze, entryPoint, exitPoint)
+	})
+	if err != nil {
+		exitOnError(err)
+	}
+
+	// Move the openings of the generated maze for the longest or the requested solution
+	entryPoint, exitPoint = placeOpenings(maze, entryExitChoice, entryPoint, exitPoint, targetDifficulty)
+
+	// Select pathfinding algorithm and define it corresponding to the ContextSolver interface
+	solver := newSolver(infrastructure.GetPathSolverChoice())
+
+	// Pathfinding
+	path := solveMaze(solver, maze, entryPoint, exitPoint)
+
+	// Rendering
+	renderer := &infrastructure.ConsoleRenderer{}
 
-	// Define the maze generator corresponding to the Generator interface
-	var generator domain.Generator
+	fmt.Println("Generated maze:")
+	renderer.RenderMaze(maze)
 
-	switch algorithmChoice {
+	fmt.Println("\nMaze with found path:")
+	renderer.RenderMazeWithPath(maze, path)
+
+	// Compare the work done by the search algorithms on the same maze
+	renderSearchStats(renderer, maze, entryPoint, exitPoint)
+
+	// Difficulty and texture of the generated maze
+	renderMetrics(renderer, maze, entryPoint, exitPoint, path)
+
+	// Compare the generators on samples of the same size, which takes a while for large mazes
+	if infrastructure.GetComparisonChoice() == 1 {
+		renderComparison(renderer, width, height)
+	}
+}
+
+// newGenerator creates the generator of the chosen algorithm.
+func newGenerator(choice int) domain.ContextGenerator {
+	switch choice {
 	case 1:
-		generator = &application.DFSGenerator{} // Pass rng to DFS generator
+		return &application.DFSGenerator{}
 	case 2:
-		generator = &application.KruskalGenerator{} // Pass rng to Kruskal generator
+		return &application.KruskalGenerator{}
+	case 3:
+		return application.NewParallelGenerator(infrastructure.GetWorkerCount())
 	default:
 		fmt.Println("Error: invalid generation algorithm choice.")
 		os.Exit(1)
 	}
 
-	// Select the method for entering start and exit points
-	entryExitChoice := infrastructure.GetEntryExitChoice()
-	entryPoint, exitPoint := infrastructure.GetEntryExitPoints(entryExitChoice, width, height)
-
-	// Maze generation
-	generator.Generate(maze, entryPoint, exitPoint)
-
-	// Select pathfinding algorithm
-	pathSolverChoice := infrastructure.GetPathSolverChoice()
-
-	// Define the pathfinding algorithm corresponding to the Solver interface
-	var solver domain.Solver
+	return nil
+}
 
-	switch pathSolverChoice {
+// newSolver creates the solver of the chosen pathfinding algorithm.
+func newSolver(choice int) domain.ContextSolver {
+	switch choice {
 	case 1:
-		solver = &application.BFSSolver{}
+		return &application.BFSSolver{}
 	case 2:
-		solver = &application.AStarSolver{}
+		return &application.AStarSolver{}
 	default:
 		fmt.Println("Error: invalid pathfinding algorithm choice.")
 		os.Exit(1)
 	}
 
-	// Pathfinding
-	path := solver.FindPath(maze, entryPoint, exitPoint)
+	return nil
+}
 
-	// Rendering
-	renderer := &infrastructure.ConsoleRenderer{}
+// solveMaze finds the path from entry to exit; an unsolvable maze is reported and gives no path.
+func solveMaze(solver domain.ContextSolver, maze *domain.Maze, entry, exit domain.Point) []domain.Point {
+	var path []domain.Point
 
-	fmt.Println("Generated maze:")
-	renderer.RenderMaze(maze)
+	err := runCancelable(func(ctx context.Context) error {
+		var solveErr error
 
-	fmt.Println("\nMaze with found path:")
-	renderer.RenderMazeWithPath(maze, path)
+		path, solveErr = solver.FindPathContext(ctx, maze, entry, exit)
+
+		return solveErr
+	})
+
+	switch {
+	case errors.Is(err, domain.ErrUnreachableExit):
+		fmt.Println("Path not found:", err)
+	case err != nil:
+		exitOnError(err)
+	}
+
+	return path
+}
+
+// placeOpenings moves the openings of the generated maze for the longest solution (choice 3)
+// or for the solution closest to the target difficulty (choice 4), other choices keep them.
+func placeOpenings(maze *domain.Maze, choice int, entry, exit domain.Point, targetDifficulty int) (newEntry, newExit domain.Point) {
+	placer := application.NewOpeningPlacer()
+
+	var err error
+
+	switch choice {
+	case 3:
+		newEntry, newExit, err = placer.PlaceLongest(maze, entry, exit)
+	case 4:
+		analyzer := analysis.NewAnalyzer()
+		difficulty := func(maze *domain.Maze, entry, exit domain.Point, path []domain.Point) float64 {
+			return analyzer.AnalyzePath(maze, entry, exit, path).Difficulty
+		}
+
+		newEntry, newExit, err = placer.PlaceForScore(maze, entry, exit, float64(targetDifficulty), difficulty)
+	default:
+		return entry, exit
+	}
+
+	if err != nil {
+		exitOnError(err)
+	}
+
+	fmt.Printf("Entry: (%d, %d), exit: (%d, %d)\n", newEntry.X, newEntry.Y, newExit.X, newExit.Y)
+
+	return newEntry, newExit
+}
+
+// renderSearchStats solves the maze with every instrumented solver and prints the work each one did.
+func renderSearchStats(renderer *infrastructure.ConsoleRenderer, maze *domain.Maze, entry, exit domain.Point) {
+	instrumentedSolvers := []struct {
+		name   string
+		solver domain.InstrumentedSolver
+	}{
+		{"BFS", &application.BFSSolver{}},
+		{"A*", &application.AStarSolver{}},
+		{"Bidirectional BFS", &application.BidirectionalBFSSolver{}},
+		{"JPS", &application.JPSSolver{}},
+	}
+
+	stats := make([]infrastructure.SolverStats, 0, len(instrumentedSolvers))
+
+	// An unsolvable maze has already been reported and shows as an empty path
+	err := runCancelable(func(ctx context.Context) error {
+		for _, s := range instrumentedSolvers {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			solverPath, solverStats, err := s.solver.FindPathWithStats(maze, entry, exit)
+			if err != nil && !errors.Is(err, domain.ErrUnreachableExit) {
+				return fmt.Errorf("%s: %w", s.name, err)
+			}
+
+			stats = append(stats, infrastructure.SolverStats{Name: s.name, PathLength: len(solverPath), Stats: solverStats})
+		}
+
+		return nil
+	})
+	if err != nil {
+		exitOnError(err)
+	}
+
+	fmt.Println("\nSearch statistics:")
+	renderer.RenderSearchStats(stats)
+}
+
+// renderMetrics prints the difficulty and texture of the maze when it has a solution.
+func renderMetrics(renderer *infrastructure.ConsoleRenderer, maze *domain.Maze, entry, exit domain.Point, path []domain.Point) {
+	if path == nil {
+		return
+	}
+
+	metrics := analysis.NewAnalyzer().AnalyzePath(maze, entry, exit, path)
+
+	fmt.Println("\nMaze metrics:")
+	renderer.RenderMetrics([]infrastructure.MetricsRow{{Name: "Generated", Metrics: metrics}})
+}
+
+// renderComparison prints the average metrics of the generators on mazes of the given size.
+func renderComparison(renderer *infrastructure.ConsoleRenderer, width, height int) {
+	rows, err := compareGenerators(width, height)
+	if err != nil {
+		exitOnError(err)
+	}
+
+	fmt.Printf("\nGenerator comparison (average of %d mazes %dx%d each):\n", comparisonSamples, width, height)
+	renderer.RenderMetrics(rows)
+}
+
+// comparisonSamples is the number of mazes each generator builds for the comparison.
+const comparisonSamples = 20
+
+// compareGenerators generates samples with DFS and Kruskal's algorithm and averages their metrics.
+func compareGenerators(width, height int) ([]infrastructure.MetricsRow, error) {
+	generators := []struct{ name, label string }{{"dfs", "DFS"}, {"kruskal", "Kruskal"}}
+
+	var jobs []application.BatchJob
+
+	for _, g := range generators {
+		for i := 0; i < comparisonSamples; i++ {
+			jobs = append(jobs, application.BatchJob{Index: len(jobs), Seed: rand.Int63(), Width: width, Height: height, Generator: g.name})
+		}
+	}
+
+	analyzer := analysis.NewAnalyzer()
+	samples := make(map[string][]analysis.Metrics)
+
+	err := runCancelable(func(ctx context.Context) error {
+		return application.NewBatchGenerator(0).Run(ctx, jobs, func(result *application.BatchResult) error {
+			metrics := analyzer.AnalyzePath(result.Maze, result.Entry, result.Exit, result.Path)
+			samples[result.Job.Generator] = append(samples[result.Job.Generator], metrics)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]infrastructure.MetricsRow, 0, len(generators))
+	for _, g := range generators {
+		rows = append(rows, infrastructure.MetricsRow{Name: g.label, Metrics: analysis.Average(samples[g.name])})
+	}
+
+	return rows, nil
+}
+
+// runCancelable runs long work with a context that is canceled on Ctrl+C or SIGTERM,
+// so in-flight generation or solving stops cleanly instead of the process being killed.
+// Work interrupted after it has already finished still reports context.Canceled.
+func runCancelable(work func(ctx context.Context) error) error {
+	runningWork.Add(1)
+	defer runningWork.Add(-1)
+
+	if err := work(interruptCtx); err != nil {
+		return err
+	}
+
+	return interruptCtx.Err()
+}
+
+// exitOnError reports the error of the interrupted work and terminates the program.
+func exitOnError(err error) {
+	if errors.Is(err, context.Canceled) {
+		terminate()
+	}
+
+	fmt.Println("Error:", err)
+	os.Exit(1)
+}
+
+// terminate reports that the user interrupted the program and exits.
+func terminate() {
+	terminateOnce.Do(func() {
+		fmt.Println("\nProgram terminated by the user.")
+		os.Exit(0)
+	})
 }
@@ -0,0 +1,190 @@
+// Package analysis measures how difficult a maze is and what its texture looks like.
+package analysis
+
+import (
+	"math"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// riverScale is the number of cells per dead end at which the river term of the difficulty reaches one half.
+const riverScale = 8
+
+// Metrics describes the structure of a maze and of its solution.
+type Metrics struct {
+	// Passages is the number of open cells.
+	Passages int
+	// DeadEnds is the number of open cells with a single open neighbor, not counting the entry and exit.
+	DeadEnds int
+	// Junctions is the number of open cells with three or more open neighbors.
+	Junctions int
+	// BranchingFactor is the average number of ways onward at a junction, zero without junctions.
+	BranchingFactor float64
+	// SolutionLength is the number of cells on the shortest path from the entry to the exit.
+	SolutionLength int
+	// SolutionRatio is the share of the open cells lying on the solution.
+	SolutionRatio float64
+	// Decisions is the number of junctions met along the solution.
+	Decisions int
+	// Turns is the number of direction changes along the solution.
+	Turns int
+	// River is the average number of cells off the solution per dead end: mazes with few long
+	// winding branches have a high river, mazes with many short stubs a low one.
+	River float64
+	// LongestCorridor is the longest stretch of steps without a junction or a dead end.
+	LongestCorridor int
+	// Difficulty combines the solution length, decisions, turns and river into a score from 0 to 100.
+	Difficulty float64
+}
+
+// Analyzer computes the metrics of mazes.
+type Analyzer struct {
+	// Solver finds the solution; BFS is used if nil.
+	Solver domain.Solver
+}
+
+// NewAnalyzer initializes the Analyzer with a BFS solver.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{Solver: &application.BFSSolver{}}
+}
+
+// Analyze solves the maze and computes its metrics.
+func (a *Analyzer) Analyze(maze *domain.Maze, entry, exit domain.Point) (Metrics, error) {
+	solver := a.Solver
+	if solver == nil {
+		solver = &application.BFSSolver{}
+	}
+
+	path, err := solver.FindPath(maze, entry, exit)
+	if err != nil {
+		return Metrics{}, err
+	}
+
+	return a.AnalyzePath(maze, entry, exit, path), nil
+}
+
+// AnalyzePath computes the metrics of the maze for an already known solution.
+func (a *Analyzer) AnalyzePath(maze *domain.Maze, entry, exit domain.Point, path []domain.Point) Metrics {
+	var m Metrics
+
+	branches := 0
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			p := domain.Point{X: x, Y: y}
+			if maze.Grid[y][x].Wall {
+				continue
+			}
+
+			m.Passages++
+
+			switch degree := a.degree(maze, p); {
+			case degree == 1 && p != entry && p != exit:
+				m.DeadEnds++
+			case degree >= 3:
+				m.Junctions++
+				branches += degree - 1
+			}
+		}
+	}
+
+	if m.Junctions > 0 {
+		m.BranchingFactor = float64(branches) / float64(m.Junctions)
+	}
+
+	m.SolutionLength = len(path)
+	if m.Passages > 0 {
+		m.SolutionRatio = float64(m.SolutionLength) / float64(m.Passages)
+	}
+
+	for i, p := range path {
+		if a.degree(maze, p) >= 3 {
+			m.Decisions++
+		}
+
+		if i >= 2 && (p.X-path[i-1].X != path[i-1].X-path[i-2].X || p.Y-path[i-1].Y != path[i-1].Y-path[i-2].Y) {
+			m.Turns++
+		}
+	}
+
+	if m.DeadEnds > 0 {
+		m.River = float64(m.Passages-m.SolutionLength) / float64(m.DeadEnds)
+	}
+
+	for _, edge := range application.NewGraphExtractor().Extract(maze).Edges {
+		m.LongestCorridor = max(m.LongestCorridor, edge.Length)
+	}
+
+	m.Difficulty = a.difficulty(m)
+
+	return m
+}
+
+// difficulty weighs four terms scaled to [0, 1]: how much of the maze the solution covers, how
+// often it forces a choice, how often it turns and how costly a wrong choice is. Choices and turns
+// happen at most on every second cell of a maze with walls between cells, hence the doubling.
+func (a *Analyzer) difficulty(m Metrics) float64 {
+	if m.SolutionLength < 2 {
+		return 0
+	}
+
+	decisions := math.Min(1, 2*float64(m.Decisions)/float64(m.SolutionLength))
+	turns := math.Min(1, 2*float64(m.Turns)/float64(m.SolutionLength-1))
+	river := m.River / (m.River + riverScale)
+
+	return 100 * (0.4*m.SolutionRatio + 0.25*decisions + 0.2*turns + 0.15*river)
+}
+
+// degree returns the number of open cells next to p.
+func (a *Analyzer) degree(maze *domain.Maze, p domain.Point) int {
+	degree := 0
+
+	for _, n := range []domain.Point{{X: p.X, Y: p.Y - 1}, {X: p.X + 1, Y: p.Y}, {X: p.X, Y: p.Y + 1}, {X: p.X - 1, Y: p.Y}} {
+		if maze.Contains(n) && !maze.Grid[n.Y][n.X].Wall {
+			degree++
+		}
+	}
+
+	return degree
+}
+
+// Average returns the mean of every metric over the samples, the counts rounded to the nearest integer.
+func Average(samples []Metrics) Metrics {
+	var sum Metrics
+
+	if len(samples) == 0 {
+		return sum
+	}
+
+	for _, m := range samples {
+		sum.Passages += m.Passages
+		sum.DeadEnds += m.DeadEnds
+		sum.Junctions += m.Junctions
+		sum.BranchingFactor += m.BranchingFactor
+		sum.SolutionLength += m.SolutionLength
+		sum.SolutionRatio += m.SolutionRatio
+		sum.Decisions += m.Decisions
+		sum.Turns += m.Turns
+		sum.River += m.River
+		sum.LongestCorridor += m.LongestCorridor
+		sum.Difficulty += m.Difficulty
+	}
+
+	count := float64(len(samples))
+	mean := func(total int) int { return int(math.Round(float64(total) / count)) }
+
+	return Metrics{
+		Passages:        mean(sum.Passages),
+		DeadEnds:        mean(sum.DeadEnds),
+		Junctions:       mean(sum.Junctions),
+		BranchingFactor: sum.BranchingFactor / count,
+		SolutionLength:  mean(sum.SolutionLength),
+		SolutionRatio:   sum.SolutionRatio / count,
+		Decisions:       mean(sum.Decisions),
+		Turns:           mean(sum.Turns),
+		River:           sum.River / count,
+		LongestCorridor: mean(sum.LongestCorridor),
+		Difficulty:      sum.Difficulty / count,
+	}
+}
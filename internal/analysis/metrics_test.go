@@ -0,0 +1,146 @@
+package analysis_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/analysis"
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// newMazeFromRows builds a maze from text rows where '#' is a wall.
+func newMazeFromRows(rows ...string) *domain.Maze {
+	maze := domain.NewMaze(len(rows[0]), len(rows))
+
+	for y, row := range rows {
+		for x, c := range row {
+			maze.Grid[y][x].Wall = c == '#'
+		}
+	}
+
+	return maze
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.#####",
+		"#...#.#",
+		"#.#.#.#",
+		"#.#...#",
+		"#.###.#",
+		"#...#.#",
+		"#####.#",
+	)
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 5, Y: 6}
+
+	m, err := analysis.NewAnalyzer().Analyze(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := analysis.Metrics{
+		Passages:        19,
+		DeadEnds:        2,
+		Junctions:       2,
+		BranchingFactor: 2,
+		SolutionLength:  11,
+		Decisions:       2,
+		Turns:           4,
+		River:           4,
+		LongestCorridor: 6,
+	}
+
+	got := m
+	got.SolutionRatio, got.Difficulty = 0, 0
+
+	if got != expected {
+		t.Fatalf("Expected metrics %+v, got %+v", expected, got)
+	}
+
+	if math.Abs(m.SolutionRatio-11.0/19) > 1e-9 {
+		t.Errorf("Expected solution ratio 11/19, got %v", m.SolutionRatio)
+	}
+
+	if m.Difficulty <= 0 || m.Difficulty > 100 {
+		t.Errorf("Expected difficulty within (0, 100], got %v", m.Difficulty)
+	}
+}
+
+func TestAnalyzer_Analyze_UnreachableExit(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#.#.#",
+		"###.#",
+	)
+
+	_, err := analysis.NewAnalyzer().Analyze(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 2})
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	avg := analysis.Average([]analysis.Metrics{
+		{DeadEnds: 4, River: 2, Difficulty: 30},
+		{DeadEnds: 6, River: 3, Difficulty: 50},
+	})
+
+	if avg.DeadEnds != 5 || avg.River != 2.5 || avg.Difficulty != 40 {
+		t.Errorf("Expected averaged metrics, got %+v", avg)
+	}
+
+	// Counts are rounded to the nearest integer rather than truncated
+	avg = analysis.Average([]analysis.Metrics{{SolutionLength: 10, Turns: 1}, {SolutionLength: 11, Turns: 1}, {SolutionLength: 11}})
+	if avg.SolutionLength != 11 || avg.Turns != 1 {
+		t.Errorf("Expected rounded counts, got %+v", avg)
+	}
+
+	if (analysis.Average(nil) != analysis.Metrics{}) {
+		t.Error("Expected zero metrics without samples")
+	}
+}
+
+func TestAnalyzer_ComparesGenerators(t *testing.T) {
+	analyzer := analysis.NewAnalyzer()
+	samples := make(map[string][]analysis.Metrics)
+
+	var jobs []application.BatchJob
+
+	for i := 0; i < 40; i++ {
+		generator := "dfs"
+		if i%2 == 1 {
+			generator = "kruskal"
+		}
+
+		jobs = append(jobs, application.BatchJob{Index: i, Seed: int64(i), Width: 31, Height: 31, Generator: generator})
+	}
+
+	err := application.NewBatchGenerator(4).Run(context.Background(), jobs, func(result *application.BatchResult) error {
+		samples[result.Job.Generator] = append(samples[result.Job.Generator],
+			analyzer.AnalyzePath(result.Maze, result.Entry, result.Exit, result.Path))
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	dfs, kruskal := analysis.Average(samples["dfs"]), analysis.Average(samples["kruskal"])
+
+	// The recursive backtracker carves long winding corridors, Kruskal's algorithm many short dead ends
+	if dfs.River <= kruskal.River {
+		t.Errorf("Expected DFS to have a higher river than Kruskal, got %.2f and %.2f", dfs.River, kruskal.River)
+	}
+
+	if dfs.LongestCorridor <= kruskal.LongestCorridor {
+		t.Errorf("Expected DFS to have longer corridors than Kruskal, got %d and %d", dfs.LongestCorridor, kruskal.LongestCorridor)
+	}
+
+	if dfs.DeadEnds >= kruskal.DeadEnds {
+		t.Errorf("Expected DFS to have fewer dead ends than Kruskal, got %d and %d", dfs.DeadEnds, kruskal.DeadEnds)
+	}
+}
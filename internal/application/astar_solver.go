@@ -2,6 +2,7 @@ package application
 
 import (
 	"container/heap"
+	"context"
 	"math"
 
 	"github.com/abakunov/mazes/internal/domain"
@@ -48,9 +49,40 @@ func (pq *PriorityQueue) Pop() interface{} {
 	return node
 }
 
-type AStarSolver struct{}
+// AStarSolver finds the cheapest path using A* with a Manhattan heuristic, or an octile one
+// when diagonal movement is enabled. Costs are scaled like in JPSSolver, so a diagonal step
+// costs about √2 straight steps.
+type AStarSolver struct {
+	// Diagonal enables 8-connected movement. Diagonal steps are only allowed when both
+	// adjacent orthogonal cells are passages, so paths never cut corners.
+	Diagonal bool
+}
+
+func (s *AStarSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(context.Background(), maze, entry, exit, &searchTrace{})
+}
+
+// FindPathContext finds the path, stopping early if the context is done.
+func (s *AStarSolver) FindPathContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(ctx, maze, entry, exit, &searchTrace{})
+}
+
+// FindPathWithStats finds the path and reports the work done by the search.
+func (s *AStarSolver) FindPathWithStats(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, domain.SearchStats, error) {
+	return collectStats(func(trace *searchTrace) ([]domain.Point, error) {
+		return s.search(context.Background(), maze, entry, exit, trace)
+	})
+}
+
+// search runs A* recording its work in the trace.
+func (s *AStarSolver) search(ctx context.Context, maze *domain.Maze, entry, exit domain.Point, trace *searchTrace) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	checker := newContextChecker(ctx)
+	heuristic := s.newHeuristic(maze, exit)
 
-func (s *AStarSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []domain.Point {
 	// Initialize priority queue
 	pq := &PriorityQueue{}
 	heap.Init(pq)
@@ -59,29 +91,36 @@ func (s *AStarSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []do
 	startNode := &Node{
 		Point:    entry,
 		Cost:     0,
-		Priority: s.heuristic(entry, exit),
+		Priority: heuristic(entry),
 		Parent:   nil,
 	}
 	heap.Push(pq, startNode)
 
-	// Store visited nodes
-	visited := make(map[domain.Point]bool)
-	visited[entry] = true
-
-	// Movement directions: up, right, down, left
-	directions := []domain.Point{
-		{X: 0, Y: -1}, // Up
-		{X: 1, Y: 0},  // Right
-		{X: 0, Y: 1},  // Down
-		{X: -1, Y: 0}, // Left
-	}
+	// Store the cheapest known cost of every reached node and the nodes already expanded
+	best := map[domain.Point]int{entry: 0}
+	closed := make(map[domain.Point]bool)
+	trace.visited = visitedFrom(best)
 
 	// A* pathfinding
 	for pq.Len() > 0 {
+		if err := checker.check(); err != nil {
+			return nil, err
+		}
+
+		frontier := pq.Len()
+
 		// Extract the node with the lowest priority
 		currentNode := heap.Pop(pq).(*Node)
 		currentPoint := currentNode.Point
 
+		if closed[currentPoint] {
+			continue // Outdated queue entry
+		}
+
+		trace.expand(frontier)
+
+		closed[currentPoint] = true
+
 		// If exit point is reached, reconstruct the path
 		if currentPoint == exit {
 			path := []domain.Point{}
@@ -89,41 +128,76 @@ func (s *AStarSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []do
 				path = append([]domain.Point{node.Point}, path...)
 			}
 
-			return path
+			return path, nil
 		}
 
-		// Iterate over all neighbors
-		for _, dir := range directions {
-			neighborPoint := domain.Point{X: currentPoint.X + dir.X, Y: currentPoint.Y + dir.Y}
-
-			// Check if the neighbor is within the maze bounds and is a passage (not a wall)
-			if neighborPoint.X >= 0 && neighborPoint.X < maze.Width && neighborPoint.Y >= 0 && neighborPoint.Y < maze.Height &&
-				!maze.Grid[neighborPoint.Y][neighborPoint.X].Wall && !visited[neighborPoint] {
-				// Calculate movement cost and priority
-				newCost := currentNode.Cost + 1
-				priority := newCost + s.heuristic(neighborPoint, exit)
-
-				// Create a node for the neighbor
-				neighborNode := &Node{
-					Point:    neighborPoint,
-					Cost:     newCost,
-					Priority: priority,
-					Parent:   currentNode,
-				}
-
-				// Add neighbor to the priority queue and mark as visited
-				heap.Push(pq, neighborNode)
-
-				visited[neighborPoint] = true
-			}
-		}
+		s.expandNeighbors(maze, pq, currentNode, best, closed, heuristic)
 	}
 
 	// Path not found
-	return nil
+	return nil, domain.ErrUnreachableExit
 }
 
-// heuristic calculates the Manhattan heuristic (distance from the current point to the exit point).
+// expandNeighbors pushes every cell reachable in one move from the node, including one-way passages
+// and teleporters, unless it is already expanded or reached more cheaply.
+func (s *AStarSolver) expandNeighbors(maze *domain.Maze, pq *PriorityQueue, currentNode *Node,
+	best map[domain.Point]int, closed map[domain.Point]bool, heuristic func(domain.Point) int) {
+	for _, neighborPoint := range nextMoves(maze, currentNode.Point, s.Diagonal) {
+		if closed[neighborPoint] {
+			continue
+		}
+
+		// Calculate movement cost and skip neighbors already reached more cheaply
+		newCost := currentNode.Cost + moveCost(currentNode.Point, neighborPoint)
+		if cost, seen := best[neighborPoint]; seen && cost <= newCost {
+			continue
+		}
+
+		best[neighborPoint] = newCost
+
+		// Add neighbor to the priority queue
+		heap.Push(pq, &Node{
+			Point:    neighborPoint,
+			Cost:     newCost,
+			Priority: newCost + heuristic(neighborPoint),
+			Parent:   currentNode,
+		})
+	}
+}
+
+// newHeuristic returns the distance to the exit, lowered wherever a teleporter could shorten
+// the way. Any route using teleporters costs at least the distance to the nearest teleporter,
+// one straight move to jump and the distance from the closest teleporter to the exit,
+// so the estimate never exceeds the real cost.
+func (s *AStarSolver) newHeuristic(maze *domain.Maze, exit domain.Point) func(domain.Point) int {
+	if len(maze.Teleports) == 0 {
+		return func(p domain.Point) int { return s.heuristic(p, exit) }
+	}
+
+	teleporters := make([]domain.Point, 0, len(maze.Teleports))
+	fromTeleporter := math.MaxInt
+
+	for t := range maze.Teleports {
+		teleporters = append(teleporters, t)
+		fromTeleporter = min(fromTeleporter, s.heuristic(t, exit))
+	}
+
+	return func(p domain.Point) int {
+		estimate := s.heuristic(p, exit)
+
+		for _, t := range teleporters {
+			estimate = min(estimate, s.heuristic(p, t)+straightCost+fromTeleporter)
+		}
+
+		return estimate
+	}
+}
+
+// heuristic returns the Manhattan distance for 4-connected movement and the octile distance otherwise.
 func (s *AStarSolver) heuristic(a, b domain.Point) int {
-	return int(math.Abs(float64(a.X-b.X)) + math.Abs(float64(a.Y-b.Y)))
+	if s.Diagonal {
+		return octileDistance(a, b)
+	}
+
+	return straightCost * (abs(a.X-b.X) + abs(a.Y-b.Y))
 }
@@ -2,6 +2,8 @@ package application_test
 
 import (
 	"container/heap"
+	"errors"
+	"math/rand"
 	"testing"
 
 	"github.com/abakunov/mazes/internal/application"
@@ -21,7 +23,10 @@ func TestAStarSolver_FindPath_SimplePath(t *testing.T) {
 	entry := domain.Point{X: 0, Y: 0}
 	exit := domain.Point{X: 2, Y: 2}
 
-	path := solver.FindPath(maze, entry, exit)
+	path, err := solver.FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 
 	if path == nil {
 		t.Fatal("Expected a path to be found, but got nil")
@@ -54,10 +59,14 @@ func TestAStarSolver_FindPath_NoPath(t *testing.T) {
 	entry := domain.Point{X: 0, Y: 0}
 	exit := domain.Point{X: 2, Y: 2}
 
-	path := solver.FindPath(maze, entry, exit)
+	path, err := solver.FindPath(maze, entry, exit)
 	if path != nil {
 		t.Error("Expected no path, but found one")
 	}
+
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
 }
 
 func TestPriorityQueue(t *testing.T) {
@@ -85,3 +94,39 @@ func TestPriorityQueue(t *testing.T) {
 		}
 	}
 }
+
+func TestAStarSolver_FindPath_Diagonal(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 200; i++ {
+		maze := newRandomGrid(4+rng.Intn(10), 4+rng.Intn(10), rng.Float64()*0.4, rng)
+		entry := domain.Point{X: 0, Y: 0}
+		exit := domain.Point{X: maze.Width - 1, Y: maze.Height - 1}
+
+		expected := dijkstraOctileCost(maze, entry, exit)
+		path, err := (&application.AStarSolver{Diagonal: true}).FindPath(maze, entry, exit)
+
+		if expected < 0 {
+			if !errors.Is(err, domain.ErrUnreachableExit) {
+				t.Fatalf("Expected ErrUnreachableExit, got %v", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for j := 1; j < len(path); j++ {
+			prev, p := path[j-1], path[j]
+			if p.X != prev.X && p.Y != prev.Y && (maze.Grid[prev.Y][p.X].Wall || maze.Grid[p.Y][prev.X].Wall) {
+				t.Fatalf("Path cuts a corner between %v and %v", prev, p)
+			}
+		}
+
+		if cost := octilePathCost(path); cost != expected {
+			t.Fatalf("Expected path cost %d, got %d", expected, cost)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// batchMaxAttempts limits how many pairs of openings are tried for a single maze.
+const batchMaxAttempts = 100
+
+// ErrUnknownGenerator is returned when a batch job names a generator the factory does not know.
+var ErrUnknownGenerator = errors.New("unknown generator")
+
+// BatchJob describes one maze of a batch. The seed fully determines the maze and its openings.
+type BatchJob struct {
+	Index     int
+	Seed      int64
+	Width     int
+	Height    int
+	Generator string
+}
+
+// BatchResult is a generated and solved maze of a batch.
+type BatchResult struct {
+	Job   BatchJob
+	Maze  *domain.Maze
+	Entry domain.Point
+	Exit  domain.Point
+	Path  []domain.Point
+}
+
+// GeneratorFactory creates the generator named in a job, drawing its randomness from rng.
+type GeneratorFactory func(name string, rng *rand.Rand) (domain.ContextGenerator, error)
+
+// NewSeededGenerator creates one of the built-in generators ("dfs", "kruskal" or "parallel") using rng.
+func NewSeededGenerator(name string, rng *rand.Rand) (domain.ContextGenerator, error) {
+	switch name {
+	case "dfs":
+		return &DFSGenerator{Rand: rng}, nil
+	case "kruskal":
+		return &KruskalGenerator{Rand: rng}, nil
+	case "parallel":
+		// A single worker keeps the batch pool in charge of the parallelism
+		return &ParallelGenerator{Workers: 1, Rand: rng}, nil
+	default:
+		return nil, ErrUnknownGenerator
+	}
+}
+
+// BatchGenerator generates and solves many mazes concurrently with a pool of goroutines.
+type BatchGenerator struct {
+	// Workers is the number of mazes processed at once; GOMAXPROCS is used if zero.
+	Workers int
+	// NewGenerator creates the generators; NewSeededGenerator is used if nil.
+	NewGenerator GeneratorFactory
+	// Solver finds the solutions and must be safe for concurrent use; BFS is used if nil.
+	Solver domain.ContextSolver
+	// Accept filters the results in the worker goroutines, so it must be safe for concurrent use;
+	// every maze is accepted if nil.
+	Accept func(result *BatchResult) bool
+}
+
+// NewBatchGenerator initializes the BatchGenerator with the number of workers.
+func NewBatchGenerator(workers int) *BatchGenerator {
+	return &BatchGenerator{Workers: workers}
+}
+
+// Run processes the jobs and passes every accepted result to handle. Results arrive in completion
+// order and handle is never called concurrently. The first error from a job or from handle stops
+// the batch and is returned.
+func (b *BatchGenerator) Run(ctx context.Context, jobs []BatchJob, handle func(result *BatchResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	queue := make(chan BatchJob)
+	results := make(chan *BatchResult)
+	errs := make(chan error, workers)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := b.work(ctx, queue, results); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+
+	go feedJobs(ctx, jobs, queue)
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+
+	for result := range results {
+		if err != nil {
+			continue
+		}
+
+		if err = handle(result); err != nil {
+			cancel()
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err = <-errs:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// feedJobs sends the jobs to the queue until they run out or the context is done, then closes the queue.
+func feedJobs(ctx context.Context, jobs []BatchJob, queue chan<- BatchJob) {
+	defer close(queue)
+
+	for _, job := range jobs {
+		select {
+		case queue <- job:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// work processes the jobs from the queue and sends the accepted results until the queue is closed,
+// the context is done or a job fails.
+func (b *BatchGenerator) work(ctx context.Context, queue <-chan BatchJob, results chan<- *BatchResult) error {
+	for job := range queue {
+		result, err := b.process(ctx, job)
+		if err != nil {
+			return err
+		}
+
+		if b.Accept != nil && !b.Accept(result) {
+			continue
+		}
+
+		select {
+		case results <- result:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// process generates and solves the maze of a single job.
+func (b *BatchGenerator) process(ctx context.Context, job BatchJob) (*BatchResult, error) {
+	rng := rand.New(rand.NewSource(job.Seed))
+
+	factory := b.NewGenerator
+	if factory == nil {
+		factory = NewSeededGenerator
+	}
+
+	generator, err := factory(job.Generator, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked before the grid is allocated; a width of at least 3 also leaves room for the openings
+	if job.Width < domain.MinMazeSize || job.Height < domain.MinMazeSize {
+		return nil, &domain.DimensionsError{Width: job.Width, Height: job.Height}
+	}
+
+	maze := domain.NewMaze(job.Width, job.Height)
+
+	solver := b.Solver
+	if solver == nil {
+		solver = &BFSSolver{}
+	}
+
+	// Not every generator connects every pair of openings, so new openings are drawn until the
+	// maze is solvable; they still depend on the seed only
+	for attempt := 0; ; attempt++ {
+		entry := domain.Point{X: 1 + rng.Intn(job.Width-2), Y: 0}
+		exit := domain.Point{X: 1 + rng.Intn(job.Width-2), Y: job.Height - 1}
+
+		err := generator.GenerateContext(ctx, maze, entry, exit)
+		if err == nil {
+			var path []domain.Point
+
+			path, err = solver.FindPathContext(ctx, maze, entry, exit)
+			if err == nil {
+				return &BatchResult{Job: job, Maze: maze, Entry: entry, Exit: exit, Path: path}, nil
+			}
+		}
+
+		if !errors.Is(err, domain.ErrUnreachableExit) || attempt+1 == batchMaxAttempts {
+			return nil, err
+		}
+	}
+}
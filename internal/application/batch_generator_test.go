@@ -0,0 +1,155 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// newBatchJobs creates jobs cycling through the generators with consecutive seeds.
+func newBatchJobs(count int, generators ...string) []application.BatchJob {
+	jobs := make([]application.BatchJob, count)
+	for i := range jobs {
+		jobs[i] = application.BatchJob{Index: i, Seed: int64(100 + i), Width: 15, Height: 11, Generator: generators[i%len(generators)]}
+	}
+
+	return jobs
+}
+
+// runBatch collects the results of a batch by job index.
+func runBatch(t *testing.T, batch *application.BatchGenerator, jobs []application.BatchJob) map[int]*application.BatchResult {
+	t.Helper()
+
+	results := make(map[int]*application.BatchResult)
+
+	err := batch.Run(context.Background(), jobs, func(result *application.BatchResult) error {
+		if _, ok := results[result.Job.Index]; ok {
+			t.Errorf("Expected job %d to be handled once", result.Job.Index)
+		}
+
+		results[result.Job.Index] = result
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return results
+}
+
+func TestBatchGenerator_Run_SolvesEveryJob(t *testing.T) {
+	jobs := newBatchJobs(30, "dfs", "kruskal", "parallel")
+	results := runBatch(t, application.NewBatchGenerator(4), jobs)
+
+	if len(results) != len(jobs) {
+		t.Fatalf("Expected %d results, got %d", len(jobs), len(results))
+	}
+
+	for _, result := range results {
+		if result.Maze.Width != 15 || result.Maze.Height != 11 {
+			t.Errorf("Expected a 15x11 maze, got %dx%d", result.Maze.Width, result.Maze.Height)
+		}
+
+		assertValidPath(t, result.Maze, result.Path, result.Entry, result.Exit)
+
+		if err := domain.NewMazeValidator(domain.AllChecks).Validate(result.Maze, result.Entry, result.Exit).Err(); err != nil {
+			t.Errorf("%s: unexpected error: %v", result.Job.Generator, err)
+		}
+	}
+}
+
+func TestBatchGenerator_Run_Reproducible(t *testing.T) {
+	jobs := newBatchJobs(12, "dfs", "kruskal", "parallel")
+
+	first := runBatch(t, application.NewBatchGenerator(1), jobs)
+	second := runBatch(t, application.NewBatchGenerator(6), jobs)
+
+	for index, a := range first {
+		b := second[index]
+		if a.Entry != b.Entry || a.Exit != b.Exit || len(a.Path) != len(b.Path) {
+			t.Fatalf("Job %d: expected the same openings and solution for the same seed", index)
+		}
+
+		for y := range a.Maze.Grid {
+			for x := range a.Maze.Grid[y] {
+				if a.Maze.Grid[y][x].Wall != b.Maze.Grid[y][x].Wall {
+					t.Fatalf("Job %d: expected the same maze for the same seed, cells differ at (%d, %d)", index, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestBatchGenerator_Run_Accept(t *testing.T) {
+	batch := application.NewBatchGenerator(3)
+	batch.Accept = func(result *application.BatchResult) bool {
+		return result.Job.Index%2 == 0
+	}
+
+	results := runBatch(t, batch, newBatchJobs(10, "kruskal"))
+
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 accepted results, got %d", len(results))
+	}
+
+	for index := range results {
+		if index%2 != 0 {
+			t.Errorf("Expected job %d to be filtered out", index)
+		}
+	}
+}
+
+func TestBatchGenerator_Run_Errors(t *testing.T) {
+	handled := func(*application.BatchResult) error { return nil }
+
+	err := application.NewBatchGenerator(2).Run(context.Background(), newBatchJobs(5, "kruskal", "unknown"), handled)
+	if !errors.Is(err, application.ErrUnknownGenerator) {
+		t.Errorf("Expected ErrUnknownGenerator, got %v", err)
+	}
+
+	for _, size := range []domain.Point{{X: 1, Y: 1}, {X: 2, Y: 21}, {X: -5, Y: 21}, {X: 21, Y: -1}} {
+		jobs := []application.BatchJob{{Width: size.X, Height: size.Y, Generator: "dfs"}}
+
+		err = application.NewBatchGenerator(2).Run(context.Background(), jobs, handled)
+		if !errors.Is(err, domain.ErrInvalidDimensions) {
+			t.Errorf("%dx%d: expected ErrInvalidDimensions, got %v", size.X, size.Y, err)
+		}
+	}
+
+	// An error from the handler stops the batch
+	errStop := errors.New("stop")
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+
+	err = application.NewBatchGenerator(4).Run(context.Background(), newBatchJobs(200, "kruskal"), func(*application.BatchResult) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected the handler error, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected the handler to be called once, got %d", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = application.NewBatchGenerator(2).Run(ctx, newBatchJobs(5, "dfs"), handled)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -1,10 +1,42 @@
 package application
 
-import "github.com/abakunov/mazes/internal/domain"
+import (
+	"context"
 
-type BFSSolver struct{}
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// BFSSolver finds the path with the fewest moves using breadth-first search.
+type BFSSolver struct {
+	// Diagonal enables 8-connected movement, where a diagonal step counts as a single move.
+	// Diagonal steps are only allowed when both adjacent orthogonal cells are passages.
+	Diagonal bool
+}
+
+func (s *BFSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(context.Background(), maze, entry, exit, &searchTrace{})
+}
+
+// FindPathContext finds the path, stopping early if the context is done.
+func (s *BFSSolver) FindPathContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(ctx, maze, entry, exit, &searchTrace{})
+}
+
+// FindPathWithStats finds the path and reports the work done by the search.
+func (s *BFSSolver) FindPathWithStats(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, domain.SearchStats, error) {
+	return collectStats(func(trace *searchTrace) ([]domain.Point, error) {
+		return s.search(context.Background(), maze, entry, exit, trace)
+	})
+}
+
+// search runs BFS recording its work in the trace.
+func (s *BFSSolver) search(ctx context.Context, maze *domain.Maze, entry, exit domain.Point, trace *searchTrace) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	checker := newContextChecker(ctx)
 
-func (s *BFSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []domain.Point {
 	// Initialize queue for BFS
 	queue := []domain.Point{entry}
 	visited := make(map[domain.Point]bool)
@@ -12,17 +44,16 @@ func (s *BFSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []doma
 
 	// Mark the entry point as visited
 	visited[entry] = true
-
-	// Movement directions: up, right, down, left
-	directions := []domain.Point{
-		{X: 0, Y: -1}, // Up
-		{X: 1, Y: 0},  // Right
-		{X: 0, Y: 1},  // Down
-		{X: -1, Y: 0}, // Left
-	}
+	trace.visited = visitedFrom(visited)
 
 	// BFS pathfinding
 	for len(queue) > 0 {
+		if err := checker.check(); err != nil {
+			return nil, err
+		}
+
+		trace.expand(len(queue))
+
 		current := queue[0]
 		queue = queue[1:]
 
@@ -33,16 +64,12 @@ func (s *BFSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []doma
 				path = append([]domain.Point{p}, path...)
 			}
 
-			return append([]domain.Point{entry}, path...)
+			return append([]domain.Point{entry}, path...), nil
 		}
 
-		// Iterate over all neighbors
-		for _, dir := range directions {
-			neighbor := domain.Point{X: current.X + dir.X, Y: current.Y + dir.Y}
-
-			// Check if the neighbor is within maze bounds and is a passage (not a wall)
-			if neighbor.X >= 0 && neighbor.X < maze.Width && neighbor.Y >= 0 && neighbor.Y < maze.Height &&
-				!maze.Grid[neighbor.Y][neighbor.X].Wall && !visited[neighbor] {
+		// Iterate over all cells reachable in one move, including one-way passages and teleporters
+		for _, neighbor := range nextMoves(maze, current, s.Diagonal) {
+			if !visited[neighbor] {
 				queue = append(queue, neighbor)
 				visited[neighbor] = true
 				parent[neighbor] = current
@@ -51,5 +78,82 @@ func (s *BFSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) []doma
 	}
 
 	// Path not found
-	return nil
+	return nil, domain.ErrUnreachableExit
+}
+
+// FindAllShortestPaths returns every path of the shortest length from the entry to the exit,
+// up to the limit. BFS records all parents a cell can be reached from with the same distance,
+// and the paths are then enumerated walking the parents back from the exit.
+func (s *BFSSolver) FindAllShortestPaths(maze *domain.Maze, entry, exit domain.Point, limit int) ([][]domain.Point, error) {
+	if limit <= 0 {
+		return nil, ErrInvalidPathLimit
+	}
+
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	queue := []domain.Point{entry}
+	distance := map[domain.Point]int{entry: 0}
+	parents := make(map[domain.Point][]domain.Point)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		// Cells farther than the exit cannot lie on a shortest path
+		if d, ok := distance[exit]; ok && distance[current] >= d {
+			break
+		}
+
+		for _, neighbor := range nextMoves(maze, current, s.Diagonal) {
+			d, seen := distance[neighbor]
+
+			switch {
+			case !seen:
+				distance[neighbor] = distance[current] + 1
+				parents[neighbor] = []domain.Point{current}
+				queue = append(queue, neighbor)
+			case d == distance[current]+1:
+				parents[neighbor] = append(parents[neighbor], current)
+			}
+		}
+	}
+
+	if _, ok := distance[exit]; !ok {
+		return nil, domain.ErrUnreachableExit
+	}
+
+	var paths [][]domain.Point
+
+	// Walk back from the exit collecting the reversed path
+	reversed := []domain.Point{exit}
+
+	var walk func(p domain.Point)
+	walk = func(p domain.Point) {
+		if len(paths) == limit {
+			return
+		}
+
+		if p == entry {
+			path := make([]domain.Point, len(reversed))
+			for i, q := range reversed {
+				path[len(reversed)-1-i] = q
+			}
+
+			paths = append(paths, path)
+
+			return
+		}
+
+		for _, parent := range parents[p] {
+			reversed = append(reversed, parent)
+			walk(parent)
+			reversed = reversed[:len(reversed)-1]
+		}
+	}
+
+	walk(exit)
+
+	return paths, nil
 }
@@ -1,6 +1,7 @@
 package application_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/abakunov/mazes/internal/application"
@@ -21,7 +22,10 @@ func TestBFSSolver_FindPath_SimplePath(t *testing.T) {
 	entry := domain.Point{X: 0, Y: 0}
 	exit := domain.Point{X: 2, Y: 2}
 
-	path := solver.FindPath(maze, entry, exit)
+	path, err := solver.FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 
 	if path == nil {
 		t.Fatal("Expected a path to be found, but got nil")
@@ -54,8 +58,59 @@ func TestBFSSolver_FindPath_NoPath(t *testing.T) {
 	entry := domain.Point{X: 0, Y: 0}
 	exit := domain.Point{X: 2, Y: 2}
 
-	path := solver.FindPath(maze, entry, exit)
+	path, err := solver.FindPath(maze, entry, exit)
 	if path != nil {
 		t.Error("Expected no path, but found one")
 	}
+
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
+
+func TestBFSSolver_FindPath_InvalidPoints(t *testing.T) {
+	maze := domain.NewMaze(3, 3)
+	maze.Grid[1][1].Wall = true
+
+	solver := &application.BFSSolver{}
+
+	if _, err := solver.FindPath(maze, domain.Point{X: 5, Y: 0}, domain.Point{X: 2, Y: 2}); !errors.Is(err, domain.ErrPointOutOfBounds) {
+		t.Errorf("Expected ErrPointOutOfBounds, got %v", err)
+	}
+
+	if _, err := solver.FindPath(maze, domain.Point{X: 0, Y: 0}, domain.Point{X: 1, Y: 1}); !errors.Is(err, domain.ErrPointInWall) {
+		t.Errorf("Expected ErrPointInWall, got %v", err)
+	}
+}
+
+func TestBFSSolver_FindPath_Diagonal(t *testing.T) {
+	// Open grid: the diagonal is walked in one move per cell
+	maze := domain.NewMaze(5, 5)
+	entry := domain.Point{X: 0, Y: 0}
+	exit := domain.Point{X: 4, Y: 4}
+
+	path, err := (&application.BFSSolver{Diagonal: true}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(path) != 5 {
+		t.Errorf("Expected path of 5 cells along the diagonal, got %v", path)
+	}
+
+	// Blocking one side of the first diagonal step forbids cutting the corner
+	maze.Grid[0][1].Wall = true
+
+	path, err = (&application.BFSSolver{Diagonal: true}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if path[1] != (domain.Point{X: 0, Y: 1}) {
+		t.Errorf("Expected the path to step down first, got %v", path)
+	}
+
+	if len(path) != 6 {
+		t.Errorf("Expected path of 6 cells, got %v", path)
+	}
 }
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// BidirectionalBFSSolver searches from the entry and the exit simultaneously and stops when the
+// two searches meet. It returns shortest paths just like BFSSolver while exploring far fewer
+// cells on open (braided) mazes. Teleporters and one-way passages are followed as by BFSSolver.
+type BidirectionalBFSSolver struct{}
+
+// bfsFrontier holds the state of one of the two searches. The search from the exit follows the
+// moves backwards, so one-way passages are walked against their arrows.
+type bfsFrontier struct {
+	queue  []domain.Point
+	dist   map[domain.Point]int
+	parent map[domain.Point]domain.Point
+	moves  func(maze *domain.Maze, p domain.Point, diagonal bool) []domain.Point
+}
+
+func newBFSFrontier(start domain.Point, moves func(*domain.Maze, domain.Point, bool) []domain.Point) *bfsFrontier {
+	return &bfsFrontier{
+		queue:  []domain.Point{start},
+		dist:   map[domain.Point]int{start: 0},
+		parent: make(map[domain.Point]domain.Point),
+		moves:  moves,
+	}
+}
+
+func (s *BidirectionalBFSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(context.Background(), maze, entry, exit, &searchTrace{})
+}
+
+// FindPathContext finds the path, stopping early if the context is done.
+func (s *BidirectionalBFSSolver) FindPathContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(ctx, maze, entry, exit, &searchTrace{})
+}
+
+// FindPathWithStats finds the path and reports the work done by both searches together.
+func (s *BidirectionalBFSSolver) FindPathWithStats(maze *domain.Maze,
+	entry, exit domain.Point) ([]domain.Point, domain.SearchStats, error) {
+	return collectStats(func(trace *searchTrace) ([]domain.Point, error) {
+		return s.search(context.Background(), maze, entry, exit, trace)
+	})
+}
+
+// search runs both searches recording their work in the trace.
+func (s *BidirectionalBFSSolver) search(ctx context.Context, maze *domain.Maze, entry, exit domain.Point,
+	trace *searchTrace) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	if entry == exit {
+		return []domain.Point{entry}, nil
+	}
+
+	forward := newBFSFrontier(entry, nextMoves)
+	backward := newBFSFrontier(exit, previousMoves)
+
+	trace.visited = func() []domain.Point {
+		visited := visitedFrom(forward.dist)()
+		for p := range backward.dist {
+			if _, ok := forward.dist[p]; !ok {
+				visited = append(visited, p)
+			}
+		}
+
+		return visited
+	}
+
+	for len(forward.queue) > 0 && len(backward.queue) > 0 {
+		// Levels are few and large, check the context before each of them
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Expand the smaller frontier by one whole level
+		current, other := forward, backward
+		if len(backward.queue) < len(forward.queue) {
+			current, other = backward, forward
+		}
+
+		if meet, ok := s.expandLevel(maze, current, other, trace); ok {
+			return s.buildPath(forward, backward, entry, exit, meet), nil
+		}
+	}
+
+	// Path not found
+	return nil, domain.ErrUnreachableExit
+}
+
+// expandLevel expands every point of the current level. If the searches meet, it returns the
+// meeting point with the shortest total distance among all meetings on this level.
+func (s *BidirectionalBFSSolver) expandLevel(maze *domain.Maze, current, other *bfsFrontier, trace *searchTrace) (domain.Point, bool) {
+	var (
+		meet     domain.Point
+		found    bool
+		bestDist int
+	)
+
+	level := current.queue
+	current.queue = nil
+
+	for i, p := range level {
+		trace.expand(len(level) - i + len(current.queue) + len(other.queue))
+
+		// Cells one move away, including one-way passages and teleporters
+		for _, neighbor := range current.moves(maze, p, false) {
+			if _, seen := current.dist[neighbor]; !seen {
+				current.dist[neighbor] = current.dist[p] + 1
+				current.parent[neighbor] = p
+				current.queue = append(current.queue, neighbor)
+			}
+
+			// Check whether the other search has already reached this cell
+			if otherDist, ok := other.dist[neighbor]; ok {
+				total := current.dist[neighbor] + otherDist
+				if !found || total < bestDist {
+					meet, bestDist, found = neighbor, total, true
+				}
+			}
+		}
+	}
+
+	return meet, found
+}
+
+// buildPath joins the half-paths of both searches at the meeting point.
+func (s *BidirectionalBFSSolver) buildPath(forward, backward *bfsFrontier, entry, exit, meet domain.Point) []domain.Point {
+	var path []domain.Point
+
+	for p := meet; p != entry; p = forward.parent[p] {
+		path = append([]domain.Point{p}, path...)
+	}
+
+	path = append([]domain.Point{entry}, path...)
+
+	for p := meet; p != exit; {
+		p = backward.parent[p]
+		path = append(path, p)
+	}
+
+	return path
+}
@@ -0,0 +1,180 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// newBraidedMaze generates a perfect maze with Kruskal's algorithm and knocks out random
+// walls between cells to create loops. The maze depends on rng only.
+func newBraidedMaze(tb testing.TB, size int, removals int, rng *rand.Rand) (maze *domain.Maze, entry, exit domain.Point) {
+	tb.Helper()
+
+	entry = domain.Point{X: 1, Y: 0}
+	exit = domain.Point{X: size - 2, Y: size - 1}
+
+	maze = domain.NewMaze(size, size)
+	if err := (&application.KruskalGenerator{Rand: rng}).Generate(maze, entry, exit); err != nil {
+		tb.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A wall between two cells has one odd and one even coordinate; pillars are kept, so every
+	// open cell stays connected
+	for i := 0; i < removals; i++ {
+		x := 1 + 2*rng.Intn((size-1)/2)
+		y := 2 + 2*rng.Intn((size-3)/2)
+
+		if rng.Intn(2) == 0 {
+			x, y = y, x
+		}
+
+		maze.Grid[y][x].Wall = false
+	}
+
+	return maze, entry, exit
+}
+
+// assertValidPath checks that the path connects entry and exit through adjacent passage cells.
+func assertValidPath(t *testing.T, maze *domain.Maze, path []domain.Point, entry, exit domain.Point) {
+	t.Helper()
+
+	if len(path) == 0 {
+		t.Fatal("Expected a path to be found, but got nil")
+	}
+
+	if path[0] != entry || path[len(path)-1] != exit {
+		t.Fatalf("Expected path from %v to %v, got %v to %v", entry, exit, path[0], path[len(path)-1])
+	}
+
+	for i, p := range path {
+		if maze.Grid[p.Y][p.X].Wall {
+			t.Fatalf("Path goes through a wall at %v", p)
+		}
+
+		if i > 0 {
+			dx, dy := p.X-path[i-1].X, p.Y-path[i-1].Y
+			if dx*dx+dy*dy != 1 {
+				t.Fatalf("Path makes a non-adjacent step from %v to %v", path[i-1], p)
+			}
+		}
+	}
+}
+
+func TestBidirectionalBFSSolver_FindPath_MatchesBFS(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 30; i++ {
+		maze, entry, exit := newBraidedMaze(t, 21, rng.Intn(60), rng)
+
+		expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+		path, err := (&application.BidirectionalBFSSolver{}).FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+
+		if len(path) != len(expected) {
+			t.Fatalf("Expected shortest path of length %d, got %d", len(expected), len(path))
+		}
+	}
+}
+
+func TestBidirectionalBFSSolver_FindPath_Passages(t *testing.T) {
+	// The two halves of the maze are joined by the teleporter only
+	maze := newMazeFromRows(
+		"#.#.#",
+		"#.#.#",
+		"#####",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 0}
+
+	if err := maze.AddTeleporter(domain.Point{X: 1, Y: 1}, domain.Point{X: 3, Y: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	path, err := (&application.BidirectionalBFSSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(path) != 4 {
+		t.Errorf("Expected a path of 4 cells through the teleporter, got %v", path)
+	}
+
+	// Teleporters and one-way passages on braided mazes give the same lengths as BFS
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 50; i++ {
+		maze, entry, exit := newBraidedMaze(t, 21, rng.Intn(60), rng)
+
+		a, b := domain.Point{X: 1 + 2*rng.Intn(10), Y: 1 + 2*rng.Intn(10)}, domain.Point{X: 1 + 2*rng.Intn(10), Y: 1 + 2*rng.Intn(10)}
+		if a != b {
+			if err := maze.AddTeleporter(a, b); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		oneWay := domain.Point{X: 1 + 2*rng.Intn(10), Y: 1 + 2*rng.Intn(10)}
+		directions := []domain.Point{{Y: -1}, {X: 1}, {Y: 1}, {X: -1}}
+		if err := maze.SetOneWay(oneWay, directions[rng.Intn(len(directions))]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected, expectedErr := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+		path, err := (&application.BidirectionalBFSSolver{}).FindPath(maze, entry, exit)
+		if !errors.Is(err, expectedErr) || len(path) != len(expected) {
+			t.Fatalf("Expected a path of length %d (error %v), got %d (error %v)", len(expected), expectedErr, len(path), err)
+		}
+	}
+}
+
+func TestBidirectionalBFSSolver_FindPath_NoPath(t *testing.T) {
+	maze := domain.NewMaze(3, 3)
+
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			maze.Grid[y][x].Wall = true
+		}
+	}
+
+	maze.Grid[0][0].Wall = false
+	maze.Grid[2][2].Wall = false
+
+	path, err := (&application.BidirectionalBFSSolver{}).FindPath(maze, domain.Point{X: 0, Y: 0}, domain.Point{X: 2, Y: 2})
+	if path != nil {
+		t.Error("Expected no path, but found one")
+	}
+
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
+
+func benchmarkSolver(b *testing.B, solver domain.Solver) {
+	maze, entry, exit := newBraidedMaze(b, 301, 20000, rand.New(rand.NewSource(1)))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = solver.FindPath(maze, entry, exit)
+	}
+}
+
+func BenchmarkBFSSolver(b *testing.B) {
+	benchmarkSolver(b, &application.BFSSolver{})
+}
+
+func BenchmarkAStarSolver(b *testing.B) {
+	benchmarkSolver(b, &application.AStarSolver{})
+}
+
+func BenchmarkBidirectionalBFSSolver(b *testing.B) {
+	benchmarkSolver(b, &application.BidirectionalBFSSolver{})
+}
@@ -0,0 +1,368 @@
+package application
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// defaultCBSMaxNodes is the number of constraint tree nodes expanded before giving up when no limit is set.
+const defaultCBSMaxNodes = 10000
+
+var (
+	// ErrConflictingAgents is returned when two agents share a start or a goal.
+	ErrConflictingAgents = errors.New("agents share a cell")
+	// ErrSearchLimitExceeded is returned when no collision-free paths are found within the node limit.
+	ErrSearchLimitExceeded = errors.New("search limit exceeded")
+)
+
+// Agent is a robot to be routed from its start to its goal.
+type Agent struct {
+	Start domain.Point
+	Goal  domain.Point
+}
+
+// CBSSolver routes several agents through the maze without collisions using Conflict-Based
+// Search. Every agent is planned on its own with A* over (cell, time) states where waiting is
+// a move; whenever two paths collide, the search branches on which of the two agents has to
+// avoid the collision and replans only that agent. The total number of moves is minimal.
+//
+// Paths are time-indexed: path[t] is the cell of the agent at step t, and an agent that has
+// arrived stays at its goal. Two agents never share a cell at the same step and never swap
+// cells between two steps.
+type CBSSolver struct {
+	// MaxNodes limits the number of constraint tree nodes expanded; 10000 is used if zero.
+	MaxNodes int
+}
+
+// NewCBSSolver initializes the CBSSolver.
+func NewCBSSolver() *CBSSolver {
+	return &CBSSolver{}
+}
+
+// cbsConstraint forbids an agent to be in a cell at a step, or to move between two cells
+// from a step to the next one.
+type cbsConstraint struct {
+	agent int
+	from  domain.Point
+	to    domain.Point
+	time  int
+	move  bool
+}
+
+// timedCell is a cell at a step.
+type timedCell struct {
+	point domain.Point
+	time  int
+}
+
+// timedMove is a move between two cells from a step to the next one.
+type timedMove struct {
+	from, to domain.Point
+	time     int
+}
+
+// constraintIndex holds the constraints of a single agent for the low-level search.
+type constraintIndex struct {
+	cells map[timedCell]bool
+	moves map[timedMove]bool
+	// last is the latest step constrained, goalBlockedUntil the latest step the goal is forbidden at.
+	last, goalBlockedUntil int
+}
+
+// cbsConflict is a collision of two agents at a step: either in the same cell, or swapping cells.
+type cbsConflict struct {
+	a, b   int
+	cellA  domain.Point
+	cellB  domain.Point
+	time   int
+	swap   bool
+	exists bool
+}
+
+// cbsNode is a node of the constraint tree with the paths planned under its constraints.
+type cbsNode struct {
+	constraints []cbsConstraint
+	paths       [][]domain.Point
+	cost        int
+	order       int
+}
+
+// cbsQueue is a priority queue of constraint tree nodes, cheapest first, then oldest first.
+type cbsQueue []*cbsNode
+
+func (q cbsQueue) Len() int { return len(q) }
+
+func (q cbsQueue) Less(i, j int) bool {
+	return q[i].cost < q[j].cost || (q[i].cost == q[j].cost && q[i].order < q[j].order)
+}
+
+func (q cbsQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *cbsQueue) Push(x interface{}) { *q = append(*q, x.(*cbsNode)) }
+
+func (q *cbsQueue) Pop() interface{} {
+	old := *q
+	node := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+
+	return node
+}
+
+// FindPaths returns a collision-free time-indexed path for every agent.
+func (s *CBSSolver) FindPaths(maze *domain.Maze, agents []Agent) ([][]domain.Point, error) {
+	return s.FindPathsContext(context.Background(), maze, agents)
+}
+
+// FindPathsContext finds the paths, stopping early if the context is done.
+func (s *CBSSolver) FindPathsContext(ctx context.Context, maze *domain.Maze, agents []Agent) ([][]domain.Point, error) {
+	if err := s.validate(maze, agents); err != nil {
+		return nil, err
+	}
+
+	checker := newContextChecker(ctx)
+
+	// Plan every agent on its own
+	root := &cbsNode{paths: make([][]domain.Point, len(agents))}
+
+	for i, agent := range agents {
+		path, err := s.findAgentPath(checker, maze, agent, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		root.paths[i] = path
+		root.cost += len(path) - 1
+	}
+
+	queue := &cbsQueue{root}
+	created := 1
+
+	for expanded := 0; queue.Len() > 0; expanded++ {
+		if expanded == s.maxNodes() {
+			return nil, ErrSearchLimitExceeded
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		node := heap.Pop(queue).(*cbsNode)
+
+		conflict := s.firstConflict(node.paths)
+		if !conflict.exists {
+			return node.paths, nil
+		}
+
+		// Branch on which of the two agents avoids the collision
+		for _, constraint := range s.resolve(conflict) {
+			child := &cbsNode{
+				constraints: append(append([]cbsConstraint{}, node.constraints...), constraint),
+				paths:       append([][]domain.Point{}, node.paths...),
+				order:       created,
+			}
+
+			path, err := s.findAgentPath(checker, maze, agents[constraint.agent], s.constraintsOf(child.constraints, constraint.agent))
+			if errors.Is(err, domain.ErrUnreachableExit) {
+				continue // This agent cannot avoid the collision
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			child.paths[constraint.agent] = path
+			child.cost = node.cost - (len(node.paths[constraint.agent]) - 1) + (len(path) - 1)
+
+			heap.Push(queue, child)
+			created++
+		}
+	}
+
+	return nil, domain.ErrUnreachableExit
+}
+
+// validate checks the start and goal of every agent and that no two agents share them.
+func (s *CBSSolver) validate(maze *domain.Maze, agents []Agent) error {
+	starts := make(map[domain.Point]bool)
+	goals := make(map[domain.Point]bool)
+
+	for _, agent := range agents {
+		if err := maze.ValidateSolvePoints(agent.Start, agent.Goal); err != nil {
+			return err
+		}
+
+		if starts[agent.Start] {
+			return &domain.PointError{Name: "start", Point: agent.Start, Err: ErrConflictingAgents}
+		}
+
+		if goals[agent.Goal] {
+			return &domain.PointError{Name: "goal", Point: agent.Goal, Err: ErrConflictingAgents}
+		}
+
+		starts[agent.Start] = true
+		goals[agent.Goal] = true
+	}
+
+	return nil
+}
+
+// findAgentPath runs A* over (cell, time) states avoiding the constraints. The agent may only
+// finish once no constraint keeps it off its goal at any later step.
+func (s *CBSSolver) findAgentPath(checker *contextChecker, maze *domain.Maze, agent Agent,
+	constraints []cbsConstraint) ([]domain.Point, error) {
+	index := s.indexConstraints(agent, constraints)
+
+	if index.cells[timedCell{agent.Start, 0}] {
+		return nil, domain.ErrUnreachableExit
+	}
+
+	// Past the last constraint waiting never helps, so a longer search cannot succeed
+	horizon := index.last + maze.Width*maze.Height
+
+	heuristic := (&AStarSolver{}).newHeuristic(maze, agent.Goal)
+	pq := &PriorityQueue{}
+	heap.Push(pq, &Node{Point: agent.Start, Priority: heuristic(agent.Start)})
+
+	closed := make(map[timedCell]bool)
+
+	for pq.Len() > 0 {
+		if err := checker.check(); err != nil {
+			return nil, err
+		}
+
+		current := heap.Pop(pq).(*Node)
+		t := current.Cost / straightCost
+
+		if closed[timedCell{current.Point, t}] {
+			continue
+		}
+
+		closed[timedCell{current.Point, t}] = true
+
+		if current.Point == agent.Goal && t > index.goalBlockedUntil {
+			return s.buildPath(current), nil
+		}
+
+		if t >= horizon {
+			continue
+		}
+
+		// Wait in place or move to a neighboring cell
+		for _, next := range append([]domain.Point{current.Point}, nextMoves(maze, current.Point, false)...) {
+			if closed[timedCell{next, t + 1}] || index.cells[timedCell{next, t + 1}] || index.moves[timedMove{current.Point, next, t}] {
+				continue
+			}
+
+			cost := current.Cost + straightCost
+			heap.Push(pq, &Node{Point: next, Cost: cost, Priority: cost + heuristic(next), Parent: current})
+		}
+	}
+
+	return nil, domain.ErrUnreachableExit
+}
+
+// indexConstraints indexes the constraints of the agent by cell and step and by move and step.
+func (s *CBSSolver) indexConstraints(agent Agent, constraints []cbsConstraint) constraintIndex {
+	index := constraintIndex{
+		cells:            make(map[timedCell]bool),
+		moves:            make(map[timedMove]bool),
+		goalBlockedUntil: -1,
+	}
+
+	for _, c := range constraints {
+		if c.move {
+			index.moves[timedMove{c.from, c.to, c.time}] = true
+		} else {
+			index.cells[timedCell{c.to, c.time}] = true
+
+			if c.to == agent.Goal {
+				index.goalBlockedUntil = max(index.goalBlockedUntil, c.time)
+			}
+		}
+
+		index.last = max(index.last, c.time)
+	}
+
+	return index
+}
+
+// buildPath returns the cells from the start to the node, one per step.
+func (s *CBSSolver) buildPath(node *Node) []domain.Point {
+	var path []domain.Point
+	for n := node; n != nil; n = n.Parent {
+		path = append([]domain.Point{n.Point}, path...)
+	}
+
+	return path
+}
+
+// constraintsOf returns the constraints that apply to the agent.
+func (s *CBSSolver) constraintsOf(constraints []cbsConstraint, agent int) []cbsConstraint {
+	var own []cbsConstraint
+
+	for _, c := range constraints {
+		if c.agent == agent {
+			own = append(own, c)
+		}
+	}
+
+	return own
+}
+
+// firstConflict finds the earliest collision between any two paths.
+func (s *CBSSolver) firstConflict(paths [][]domain.Point) cbsConflict {
+	longest := 0
+	for _, path := range paths {
+		longest = max(longest, len(path))
+	}
+
+	for t := 0; t < longest; t++ {
+		for a := 0; a < len(paths); a++ {
+			for b := a + 1; b < len(paths); b++ {
+				cellA, cellB := s.position(paths[a], t), s.position(paths[b], t)
+
+				if cellA == cellB {
+					return cbsConflict{a: a, b: b, cellA: cellA, cellB: cellB, time: t, exists: true}
+				}
+
+				if s.position(paths[a], t+1) == cellB && s.position(paths[b], t+1) == cellA {
+					return cbsConflict{a: a, b: b, cellA: cellA, cellB: cellB, time: t, swap: true, exists: true}
+				}
+			}
+		}
+	}
+
+	return cbsConflict{}
+}
+
+// resolve returns the two constraints, one per agent, either of which removes the conflict.
+func (s *CBSSolver) resolve(c cbsConflict) []cbsConstraint {
+	if c.swap {
+		return []cbsConstraint{
+			{agent: c.a, from: c.cellA, to: c.cellB, time: c.time, move: true},
+			{agent: c.b, from: c.cellB, to: c.cellA, time: c.time, move: true},
+		}
+	}
+
+	return []cbsConstraint{
+		{agent: c.a, to: c.cellA, time: c.time},
+		{agent: c.b, to: c.cellB, time: c.time},
+	}
+}
+
+// position returns the cell of the agent at the step; after arriving it stays at the goal.
+func (s *CBSSolver) position(path []domain.Point, t int) domain.Point {
+	return path[min(t, len(path)-1)]
+}
+
+func (s *CBSSolver) maxNodes() int {
+	if s.MaxNodes > 0 {
+		return s.MaxNodes
+	}
+
+	return defaultCBSMaxNodes
+}
@@ -0,0 +1,214 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// positionAt returns the cell of the agent at the step, keeping it at its goal after arrival.
+func positionAt(path []domain.Point, t int) domain.Point {
+	return path[min(t, len(path)-1)]
+}
+
+// findConflict looks for two agents in the same cell at the same step (a vertex conflict)
+// or swapping cells between two steps (a swap conflict).
+func findConflict(paths [][]domain.Point) (kind string, a, b, t int, found bool) {
+	longest := 0
+	for _, path := range paths {
+		longest = max(longest, len(path))
+	}
+
+	for t = 0; t < longest; t++ {
+		for a = 0; a < len(paths); a++ {
+			for b = a + 1; b < len(paths); b++ {
+				if positionAt(paths[a], t) == positionAt(paths[b], t) {
+					return "vertex", a, b, t, true
+				}
+
+				if positionAt(paths[a], t) == positionAt(paths[b], t+1) && positionAt(paths[a], t+1) == positionAt(paths[b], t) {
+					return "swap", a, b, t, true
+				}
+			}
+		}
+	}
+
+	return "", 0, 0, 0, false
+}
+
+// assertCollisionFree checks that every path walks from the start to the goal of its agent
+// one step or wait at a time and that no two agents collide.
+func assertCollisionFree(t *testing.T, maze *domain.Maze, agents []application.Agent, paths [][]domain.Point) {
+	t.Helper()
+
+	if len(paths) != len(agents) {
+		t.Fatalf("Expected %d paths, got %d", len(agents), len(paths))
+	}
+
+	for i, path := range paths {
+		if len(path) == 0 || path[0] != agents[i].Start || path[len(path)-1] != agents[i].Goal {
+			t.Fatalf("Expected agent %d to walk from %v to %v, got %v", i, agents[i].Start, agents[i].Goal, path)
+		}
+
+		for j := 1; j < len(path); j++ {
+			if dx, dy := path[j].X-path[j-1].X, path[j].Y-path[j-1].Y; dx*dx+dy*dy > 1 || maze.Grid[path[j].Y][path[j].X].Wall {
+				t.Fatalf("Expected agent %d to wait or step to an adjacent passage, got %v -> %v", i, path[j-1], path[j])
+			}
+		}
+	}
+
+	if kind, a, b, step, found := findConflict(paths); found {
+		t.Fatalf("Expected no collisions, got a %s conflict between agents %d and %d at step %d", kind, a, b, step)
+	}
+}
+
+func TestCBSSolver_FindPaths_SwapInCorridor(t *testing.T) {
+	// Two agents swap ends of a corridor with a single side pocket
+	maze := newMazeFromRows(
+		"#######",
+		"#.....#",
+		"###.###",
+		"#######",
+	)
+	agents := []application.Agent{
+		{Start: domain.Point{X: 1, Y: 1}, Goal: domain.Point{X: 5, Y: 1}},
+		{Start: domain.Point{X: 5, Y: 1}, Goal: domain.Point{X: 1, Y: 1}},
+	}
+
+	// Planned independently they run into each other
+	var independent [][]domain.Point
+
+	for _, agent := range agents {
+		path, err := (&application.AStarSolver{}).FindPath(maze, agent.Start, agent.Goal)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		independent = append(independent, path)
+	}
+
+	if _, _, _, _, found := findConflict(independent); !found {
+		t.Fatal("Expected independently planned paths to collide")
+	}
+
+	paths, err := application.NewCBSSolver().FindPaths(maze, agents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertCollisionFree(t, maze, agents, paths)
+
+	// One agent steps into the pocket and back out, the other waits once for it to get there
+	if cost := len(paths[0]) + len(paths[1]) - 2; cost != 11 {
+		t.Errorf("Expected 11 moves in total, got %d", cost)
+	}
+}
+
+func TestCBSSolver_FindPaths_SwapConflict(t *testing.T) {
+	// Neighbors trading places in a ring must not pass through each other
+	maze := newMazeFromRows(
+		"#####",
+		"#...#",
+		"#.#.#",
+		"#...#",
+		"#####",
+	)
+	agents := []application.Agent{
+		{Start: domain.Point{X: 1, Y: 1}, Goal: domain.Point{X: 2, Y: 1}},
+		{Start: domain.Point{X: 2, Y: 1}, Goal: domain.Point{X: 1, Y: 1}},
+	}
+
+	paths, err := application.NewCBSSolver().FindPaths(maze, agents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertCollisionFree(t, maze, agents, paths)
+}
+
+func TestCBSSolver_FindPaths_RandomGrids(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+	solved := 0
+
+	for i := 0; i < 20; i++ {
+		maze := newRandomGrid(8, 8, 0.15, rng)
+
+		// Pick starts and goals among the cells connected to the corner
+		field, err := application.NewFlowFieldBuilder().Build(maze, domain.Point{X: 0, Y: 0})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var cells []domain.Point
+
+		for y := 0; y < maze.Height; y++ {
+			for x := 0; x < maze.Width; x++ {
+				if p := (domain.Point{X: x, Y: y}); field.Distance(p) != application.Unreachable {
+					cells = append(cells, p)
+				}
+			}
+		}
+
+		if len(cells) < 8 {
+			continue
+		}
+
+		rng.Shuffle(len(cells), func(a, b int) { cells[a], cells[b] = cells[b], cells[a] })
+
+		var agents []application.Agent
+
+		for j := 0; j < 4; j++ {
+			agents = append(agents, application.Agent{Start: cells[2*j], Goal: cells[2*j+1]})
+		}
+
+		paths, err := application.NewCBSSolver().FindPaths(maze, agents)
+		if errors.Is(err, domain.ErrUnreachableExit) {
+			continue // An agent may be walled in by another one parked at its goal
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertCollisionFree(t, maze, agents, paths)
+
+		solved++
+	}
+
+	// Blocked instances are rare, most of the grids have to be solved
+	if solved < 15 {
+		t.Errorf("Expected at least 15 of 20 instances to be solved, got %d", solved)
+	}
+}
+
+func TestCBSSolver_FindPaths_Errors(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+
+	shared := []application.Agent{
+		{Start: domain.Point{X: 0, Y: 0}, Goal: domain.Point{X: 4, Y: 4}},
+		{Start: domain.Point{X: 4, Y: 0}, Goal: domain.Point{X: 4, Y: 4}},
+	}
+	if _, err := application.NewCBSSolver().FindPaths(maze, shared); !errors.Is(err, application.ErrConflictingAgents) {
+		t.Errorf("Expected ErrConflictingAgents, got %v", err)
+	}
+
+	// Swapping in a dead-end corridor is impossible
+	corridor := newMazeFromRows(
+		"#####",
+		"#...#",
+		"#####",
+	)
+	swap := []application.Agent{
+		{Start: domain.Point{X: 1, Y: 1}, Goal: domain.Point{X: 3, Y: 1}},
+		{Start: domain.Point{X: 3, Y: 1}, Goal: domain.Point{X: 1, Y: 1}},
+	}
+
+	// Every constraint only delays the swap, so the search runs into its node limit
+	_, err := (&application.CBSSolver{MaxNodes: 50}).FindPaths(corridor, swap)
+	if !errors.Is(err, application.ErrSearchLimitExceeded) {
+		t.Errorf("Expected ErrSearchLimitExceeded, got %v", err)
+	}
+}
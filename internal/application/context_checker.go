@@ -0,0 +1,27 @@
+package application
+
+import "context"
+
+// contextCheckInterval is the number of loop iterations between two context checks,
+// so that hot loops do not pay for a synchronized call on every step.
+const contextCheckInterval = 1024
+
+// contextChecker periodically reports whether the context has been canceled.
+type contextChecker struct {
+	ctx   context.Context
+	steps int
+}
+
+func newContextChecker(ctx context.Context) *contextChecker {
+	return &contextChecker{ctx: ctx}
+}
+
+// check returns the context error on the first call and then every contextCheckInterval calls.
+func (c *contextChecker) check() error {
+	c.steps++
+	if c.steps%contextCheckInterval != 1 {
+		return nil
+	}
+
+	return c.ctx.Err()
+}
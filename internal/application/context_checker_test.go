@@ -0,0 +1,72 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestGenerateContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	generators := map[string]domain.ContextGenerator{
+		"DFS":     &application.DFSGenerator{},
+		"Kruskal": &application.KruskalGenerator{},
+	}
+
+	for name, generator := range generators {
+		maze := domain.NewMaze(101, 101)
+
+		err := generator.GenerateContext(ctx, maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 99, Y: 100})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", name, err)
+		}
+	}
+}
+
+func TestKruskalGenerator_GenerateContext_ImpossibleOpening(t *testing.T) {
+	// This exit touches two cells of the last column and would always close a loop, so it is
+	// rejected before the first attempt rather than after retrying until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	maze := domain.NewMaze(302, 302)
+
+	err := (&application.KruskalGenerator{}).GenerateContext(ctx, maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 301, Y: 2})
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
+
+func TestFindPathContext(t *testing.T) {
+	maze, entry, exit := newBraidedMaze(t, 41, 100, rand.New(rand.NewSource(8)))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	solvers := map[string]domain.ContextSolver{
+		"BFS":               &application.BFSSolver{},
+		"A*":                &application.AStarSolver{},
+		"Bidirectional BFS": &application.BidirectionalBFSSolver{},
+		"JPS":               &application.JPSSolver{},
+	}
+
+	for name, solver := range solvers {
+		if _, err := solver.FindPathContext(canceled, maze, entry, exit); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", name, err)
+		}
+
+		path, err := solver.FindPathContext(context.Background(), maze, entry, exit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+	}
+}
@@ -0,0 +1,83 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// DeadEndFillingSolver fills every dead end, and the corridors leading to it, until only
+// the cells on the way from the entry to the exit remain open. In a perfect maze what is
+// left is exactly the solution; in a braided maze the shortest of the remaining routes is taken.
+type DeadEndFillingSolver struct{}
+
+func (s *DeadEndFillingSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	path, _, err := s.FindPathWithTrajectory(maze, entry, exit)
+	return path, err
+}
+
+// FindPathWithTrajectory returns the path along with the cells in the order they were filled,
+// followed by the path itself.
+func (s *DeadEndFillingSolver) FindPathWithTrajectory(maze *domain.Maze,
+	entry, exit domain.Point) (path, trajectory []domain.Point, err error) {
+	if err = maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, nil, err
+	}
+
+	filled := make(map[domain.Point]bool)
+
+	openDegree := func(p domain.Point) int {
+		degree := 0
+
+		for _, n := range passageNeighbors(maze, p) {
+			if !filled[n] {
+				degree++
+			}
+		}
+
+		return degree
+	}
+
+	// Collect the initial dead ends
+	var queue []domain.Point
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			p := domain.Point{X: x, Y: y}
+			if isPassage(maze, p) && p != entry && p != exit && openDegree(p) <= 1 {
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	// Fill dead ends; a neighbor that becomes a dead end is filled next
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if filled[current] || openDegree(current) > 1 {
+			continue
+		}
+
+		filled[current] = true
+		trajectory = append(trajectory, current)
+
+		for _, n := range passageNeighbors(maze, current) {
+			if !filled[n] && n != entry && n != exit && openDegree(n) <= 1 {
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	// Walk what is left
+	remaining := domain.NewMaze(maze.Width, maze.Height)
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			remaining.Grid[y][x].Wall = maze.Grid[y][x].Wall || filled[domain.Point{X: x, Y: y}]
+		}
+	}
+
+	path, err = (&BFSSolver{}).FindPath(remaining, entry, exit)
+	if err != nil {
+		return nil, trajectory, err
+	}
+
+	return path, append(trajectory, path...), nil
+}
@@ -0,0 +1,64 @@
+package application_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestDeadEndFillingSolver_PerfectMaze(t *testing.T) {
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 19, Y: 20}
+
+	maze := domain.NewMaze(21, 21)
+	if err := (&application.KruskalGenerator{}).Generate(maze, entry, exit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+	path, trajectory, err := (&application.DeadEndFillingSolver{}).FindPathWithTrajectory(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertValidPath(t, maze, path, entry, exit)
+
+	if len(path) != len(expected) {
+		t.Errorf("Expected path of length %d, got %d", len(expected), len(path))
+	}
+
+	// Every passage cell is either filled or on the solution
+	passages := 0
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if !maze.Grid[y][x].Wall {
+				passages++
+			}
+		}
+	}
+
+	if len(trajectory) != passages {
+		t.Errorf("Expected %d filled and path cells, got %d", passages, len(trajectory))
+	}
+}
+
+func TestDeadEndFillingSolver_BraidedMaze(t *testing.T) {
+	maze, entry, exit := newBraidedMaze(t, 21, 40, rand.New(rand.NewSource(6)))
+
+	expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+	path, err := (&application.DeadEndFillingSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertValidPath(t, maze, path, entry, exit)
+
+	if len(path) != len(expected) {
+		t.Errorf("Expected path of length %d, got %d", len(expected), len(path))
+	}
+}
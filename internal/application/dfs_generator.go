@@ -1,13 +1,24 @@
 package application
 
 import (
+	"context"
 	"crypto/rand"
+	"fmt"
 	"math/big"
+	mathrand "math/rand"
 
 	"github.com/abakunov/mazes/internal/domain"
 )
 
-type DFSGenerator struct{}
+// dfsValidator checks that the openings of a generated maze are connected.
+var dfsValidator = domain.NewMazeValidator(domain.CheckReachability)
+
+// DFSGenerator generates perfect mazes with a randomized depth-first search. Maze cells lie on odd
+// coordinates with walls between them, as in KruskalGenerator.
+type DFSGenerator struct {
+	// Rand makes the generation reproducible; crypto/rand is used if nil.
+	Rand *mathrand.Rand
+}
 
 // NewDFSGenerator initializes the DFSGenerator.
 func NewDFSGenerator() *DFSGenerator {
@@ -15,7 +26,18 @@ func NewDFSGenerator() *DFSGenerator {
 }
 
 // Generate creates a maze using the DFS (Depth-First Search) algorithm.
-func (p *DFSGenerator) Generate(maze *domain.Maze, entryPoint, exitPoint domain.Point) {
+func (p *DFSGenerator) Generate(maze *domain.Maze, entryPoint, exitPoint domain.Point) error {
+	return p.GenerateContext(context.Background(), maze, entryPoint, exitPoint)
+}
+
+// GenerateContext creates a maze using DFS, stopping early if the context is done.
+func (p *DFSGenerator) GenerateContext(ctx context.Context, maze *domain.Maze, entryPoint, exitPoint domain.Point) error {
+	if err := maze.ValidateGenerationPoints(entryPoint, exitPoint); err != nil {
+		return err
+	}
+
+	checker := newContextChecker(ctx)
+
 	// Initialize all cells as walls
 	for y := 0; y < maze.Height; y++ {
 		for x := 0; x < maze.Width; x++ {
@@ -26,13 +48,22 @@ func (p *DFSGenerator) Generate(maze *domain.Maze, entryPoint, exitPoint domain.
 	// Set outer boundaries as walls, except for entry and exit points
 	p.setOuterWalls(maze, entryPoint, exitPoint)
 
-	// Start generation from the entry point, excluding outer boundaries
-	stack := []domain.Point{entryPoint}
-	maze.Grid[entryPoint.Y][entryPoint.X].Visited = true
-	maze.Grid[entryPoint.Y][entryPoint.X].Wall = false
+	// Openings next to a wall between two cells need that wall open to join the maze without a loop
+	forced := p.forcedWalls(maze, entryPoint, exitPoint)
+
+	// Start generation from the cell next to the entry point, excluding outer boundaries
+	start := inward(maze, entryPoint)
+	start.X -= 1 - start.X%2
+	start.Y -= 1 - start.Y%2
+
+	stack := p.visit(maze, nil, start, forced)
 
 	// Depth-First Search
 	for len(stack) > 0 {
+		if err := checker.check(); err != nil {
+			return err
+		}
+
 		// Take the last point from the stack
 		current := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
@@ -44,31 +75,100 @@ func (p *DFSGenerator) Generate(maze *domain.Maze, entryPoint, exitPoint domain.
 			stack = append(stack, current)
 
 			// Choose a random unvisited neighbor using crypto/rand
-			next := neighbors[p.getRandomIndex(len(neighbors))]
+			index, err := p.getRandomIndex(len(neighbors))
+			if err != nil {
+				return err
+			}
+
+			next := neighbors[index]
 
 			// Remove the wall between the current cell and the chosen neighbor
 			p.removeWallBetween(maze, current, next)
 
 			// Mark the neighbor as visited and add it to the stack
-			maze.Grid[next.Y][next.X].Visited = true
-			maze.Grid[next.Y][next.X].Wall = false
+			stack = p.visit(maze, stack, next, forced)
+		}
+	}
+
+	p.connectOpenings(maze, entryPoint, exitPoint)
+
+	if dfsValidator.Validate(maze, entryPoint, exitPoint).Has(domain.UnreachableExit) {
+		return &domain.PointError{Name: "exit", Point: exitPoint, Err: domain.ErrUnreachableExit}
+	}
+
+	return nil
+}
 
-			stack = append(stack, next)
+// forcedWalls returns the cells on either side of the walls the openings need open, each cell
+// mapped to its partners.
+func (p *DFSGenerator) forcedWalls(maze *domain.Maze, openings ...domain.Point) map[domain.Point][]domain.Point {
+	forced := make(map[domain.Point][]domain.Point)
+
+	for _, opening := range openings {
+		if a, b, ok := p.openingCells(maze, opening); ok {
+			forced[a] = append(forced[a], b)
+			forced[b] = append(forced[b], a)
+		}
+	}
+
+	return forced
+}
+
+// connectOpenings joins the openings to the maze through the points next to them, unless those are on the border.
+func (p *DFSGenerator) connectOpenings(maze *domain.Maze, openings ...domain.Point) {
+	for _, opening := range openings {
+		if inner := inward(maze, opening); inner.X > 0 && inner.X < maze.Width-1 && inner.Y > 0 && inner.Y < maze.Height-1 {
+			maze.Grid[inner.Y][inner.X].Wall = false
+		}
+	}
+}
+
+// visit marks the cell as visited and pushes it to the stack. Cells joined to it by a forced wall
+// are visited right away through that wall, so the wall becomes part of the maze before those
+// cells can be reached in any other way.
+func (p *DFSGenerator) visit(maze *domain.Maze, stack []domain.Point, cell domain.Point,
+	forced map[domain.Point][]domain.Point) []domain.Point {
+	maze.Grid[cell.Y][cell.X].Visited = true
+	maze.Grid[cell.Y][cell.X].Wall = false
+	stack = append(stack, cell)
+
+	for _, partner := range forced[cell] {
+		if !maze.Grid[partner.Y][partner.X].Visited {
+			p.removeWallBetween(maze, cell, partner)
+			stack = p.visit(maze, stack, partner, forced)
 		}
 	}
 
-	// Connect the exit point to the maze if it is isolated
-	p.connectExitPoint(maze, exitPoint)
+	return stack
 }
 
-// getRandomIndex generates a random index using crypto/rand.
-func (p *DFSGenerator) getRandomIndex(maxI int) int {
+// openingCells returns the two cells on either side of the point next to the opening when that
+// point is a wall between them.
+func (p *DFSGenerator) openingCells(maze *domain.Maze, opening domain.Point) (a, b domain.Point, ok bool) {
+	inner := inward(maze, opening)
+
+	switch {
+	case inner.X%2 == 0 && inner.Y%2 == 1 && inner.X+1 < maze.Width-1:
+		return domain.Point{X: inner.X - 1, Y: inner.Y}, domain.Point{X: inner.X + 1, Y: inner.Y}, true
+	case inner.X%2 == 1 && inner.Y%2 == 0 && inner.Y+1 < maze.Height-1:
+		return domain.Point{X: inner.X, Y: inner.Y - 1}, domain.Point{X: inner.X, Y: inner.Y + 1}, true
+	default:
+		return a, b, false
+	}
+}
+
+// getRandomIndex generates a random index using crypto/rand, or the generator's source if set.
+func (p *DFSGenerator) getRandomIndex(maxI int) (int, error) {
+	if p.Rand != nil {
+		return p.Rand.Intn(maxI), nil
+	}
+
 	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(maxI)))
 	if err != nil {
-		panic("crypto/rand failed to generate a random number") // Handle error appropriately in production code
+		return 0, fmt.Errorf("crypto/rand failed to generate a random number: %w", err)
 	}
 
-	return int(nBig.Int64())
+	return int(nBig.Int64()), nil
 }
 
 // setOuterWalls sets the outer boundaries as walls, leaving passages at the entry and exit points.
@@ -88,23 +188,6 @@ func (p *DFSGenerator) setOuterWalls(maze *domain.Maze, entryPoint, exitPoint do
 	maze.Grid[exitPoint.Y][exitPoint.X].Wall = false
 }
 
-// connectExitPoint connects the exit point to the maze if it is isolated.
-func (p *DFSGenerator) connectExitPoint(maze *domain.Maze, exitPoint domain.Point) {
-	// Find neighbors of the exit point
-	neighbors := p.getUnvisitedNeighbors(maze, exitPoint)
-	if len(neighbors) > 0 {
-		// Choose a random neighbor
-		next := neighbors[p.getRandomIndex(len(neighbors))]
-
-		// Remove the wall between the exit point and the chosen neighbor
-		p.removeWallBetween(maze, exitPoint, next)
-
-		// Mark the neighbor as visited
-		maze.Grid[next.Y][next.X].Visited = true
-		maze.Grid[next.Y][next.X].Wall = false
-	}
-}
-
 // getUnvisitedNeighbors returns a list of unvisited neighbors for the specified cell.
 func (p *DFSGenerator) getUnvisitedNeighbors(maze *domain.Maze, cell domain.Point) []domain.Point {
 	var neighbors []domain.Point
@@ -0,0 +1,131 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestGenerators_InvalidInput(t *testing.T) {
+	generators := map[string]domain.Generator{
+		"DFS":      application.NewDFSGenerator(),
+		"Kruskal":  &application.KruskalGenerator{},
+		"Parallel": application.NewParallelGenerator(2),
+	}
+
+	for name, generator := range generators {
+		err := generator.Generate(domain.NewMaze(1, 1), domain.Point{X: 0, Y: 0}, domain.Point{X: 0, Y: 0})
+		if !errors.Is(err, domain.ErrInvalidDimensions) {
+			t.Errorf("%s: expected ErrInvalidDimensions, got %v", name, err)
+		}
+
+		err = generator.Generate(domain.NewMaze(7, 7), domain.Point{X: 1, Y: 0}, domain.Point{X: 7, Y: 3})
+		if !errors.Is(err, domain.ErrPointOutOfBounds) {
+			t.Errorf("%s: expected ErrPointOutOfBounds, got %v", name, err)
+		}
+	}
+}
+
+func TestGenerators_InvalidOpenings(t *testing.T) {
+	generators := map[string]domain.Generator{
+		"DFS":      application.NewDFSGenerator(),
+		"Kruskal":  &application.KruskalGenerator{},
+		"Parallel": application.NewParallelGenerator(2),
+	}
+
+	tests := []struct {
+		name        string
+		entry, exit domain.Point
+	}{
+		{"corner entry", domain.Point{X: 0, Y: 0}, domain.Point{X: 5, Y: 6}},
+		{"corner exit", domain.Point{X: 1, Y: 0}, domain.Point{X: 6, Y: 6}},
+		{"interior entry", domain.Point{X: 3, Y: 3}, domain.Point{X: 5, Y: 6}},
+	}
+
+	for name, generator := range generators {
+		for _, tt := range tests {
+			err := generator.Generate(domain.NewMaze(7, 7), tt.entry, tt.exit)
+			if !errors.Is(err, domain.ErrInvalidOpening) {
+				t.Errorf("%s, %s: expected ErrInvalidOpening, got %v", name, tt.name, err)
+			}
+		}
+	}
+}
+
+func TestKruskalGenerator_Generate_UnreachableExit(t *testing.T) {
+	// In an even-sized maze this exit touches two cells of the last column, so it always closes a loop
+	err := (&application.KruskalGenerator{}).Generate(domain.NewMaze(6, 6), domain.Point{X: 1, Y: 0}, domain.Point{X: 5, Y: 2})
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
+
+func TestKruskalGenerator_Generate_PerfectMaze(t *testing.T) {
+	validator := domain.NewMazeValidator(domain.AllChecks)
+
+	tests := []struct {
+		name          string
+		width, height int
+		entry, exit   domain.Point
+	}{
+		{"aligned openings", 21, 21, domain.Point{X: 1, Y: 0}, domain.Point{X: 19, Y: 20}},
+		{"side openings", 31, 15, domain.Point{X: 0, Y: 7}, domain.Point{X: 30, Y: 3}},
+		{"opening between cells", 21, 11, domain.Point{X: 1, Y: 0}, domain.Point{X: 0, Y: 4}},
+		{"single cell", 3, 3, domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 2}},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			maze := domain.NewMaze(tt.width, tt.height)
+			if err := (&application.KruskalGenerator{}).Generate(maze, tt.entry, tt.exit); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+
+			if err := validator.Validate(maze, tt.entry, tt.exit).Err(); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+		}
+	}
+}
+
+func TestDFSGenerator_Generate_PerfectMaze(t *testing.T) {
+	validator := domain.NewMazeValidator(domain.AllChecks)
+
+	tests := []struct {
+		name          string
+		width, height int
+		entry, exit   domain.Point
+	}{
+		{"aligned openings", 11, 11, domain.Point{X: 1, Y: 0}, domain.Point{X: 9, Y: 10}},
+		{"side openings", 31, 15, domain.Point{X: 0, Y: 7}, domain.Point{X: 30, Y: 3}},
+		{"openings between cells", 21, 11, domain.Point{X: 2, Y: 0}, domain.Point{X: 0, Y: 4}},
+		{"openings sharing a cell", 21, 11, domain.Point{X: 2, Y: 0}, domain.Point{X: 4, Y: 0}},
+		{"even size", 20, 14, domain.Point{X: 2, Y: 0}, domain.Point{X: 17, Y: 13}},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			maze := domain.NewMaze(tt.width, tt.height)
+
+			generator := &application.DFSGenerator{Rand: rand.New(rand.NewSource(int64(i)))}
+			if err := generator.Generate(maze, tt.entry, tt.exit); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+
+			if err := validator.Validate(maze, tt.entry, tt.exit).Err(); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+		}
+	}
+}
+
+func TestDFSGenerator_Generate_UnreachableExit(t *testing.T) {
+	// In an even-sized maze this exit is next to neither a cell nor a wall between two cells
+	err := application.NewDFSGenerator().Generate(domain.NewMaze(6, 6), domain.Point{X: 1, Y: 0}, domain.Point{X: 4, Y: 5})
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
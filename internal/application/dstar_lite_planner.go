@@ -0,0 +1,261 @@
+package application
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// dstarInfinity stands for an unreachable cell; it is small enough that adding a step cost does not overflow.
+const dstarInfinity = math.MaxInt / 2
+
+// DStarLitePlanner keeps the shortest path from an agent to the goal up to date while walls
+// appear and disappear, using D* Lite. The search runs backwards from the goal, so when a wall
+// changes only the cells whose distance to the goal is affected are recomputed, and moving the
+// agent does not invalidate any work. Teleporters and one-way passages are ignored.
+type DStarLitePlanner struct {
+	maze  *domain.Maze
+	start domain.Point
+	goal  domain.Point
+	last  domain.Point
+
+	// g is the distance to the goal settled by the last search, rhs the one-step lookahead value
+	g   map[domain.Point]int
+	rhs map[domain.Point]int
+	km  int
+
+	queue *dstarQueue
+	// open holds the current key of every cell in the queue; other queue entries are outdated
+	open map[domain.Point]dstarKey
+}
+
+// dstarKey orders the cells in the D* Lite queue lexicographically.
+type dstarKey [2]int
+
+func (k dstarKey) less(other dstarKey) bool {
+	return k[0] < other[0] || (k[0] == other[0] && k[1] < other[1])
+}
+
+// dstarEntry is a cell queued with the key it had when it was pushed.
+type dstarEntry struct {
+	point domain.Point
+	key   dstarKey
+}
+
+// dstarQueue is a priority queue of D* Lite entries ordered by key.
+type dstarQueue []dstarEntry
+
+func (q dstarQueue) Len() int { return len(q) }
+
+func (q dstarQueue) Less(i, j int) bool { return q[i].key.less(q[j].key) }
+
+func (q dstarQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *dstarQueue) Push(x interface{}) { *q = append(*q, x.(dstarEntry)) }
+
+func (q *dstarQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return entry
+}
+
+// NewDStarLitePlanner plans the initial path from the start to the goal.
+func NewDStarLitePlanner(maze *domain.Maze, start, goal domain.Point) (*DStarLitePlanner, error) {
+	if err := maze.ValidateSolvePoints(start, goal); err != nil {
+		return nil, err
+	}
+
+	p := &DStarLitePlanner{
+		maze:  maze,
+		start: start,
+		goal:  goal,
+		last:  start,
+		g:     make(map[domain.Point]int),
+		rhs:   map[domain.Point]int{goal: 0},
+		queue: &dstarQueue{},
+		open:  make(map[domain.Point]dstarKey),
+	}
+
+	p.push(goal)
+	p.computeShortestPath()
+
+	return p, nil
+}
+
+// Path returns the current shortest path from the agent to the goal.
+func (p *DStarLitePlanner) Path() ([]domain.Point, error) {
+	if err := p.maze.ValidateSolvePoints(p.start, p.goal); err != nil {
+		return nil, err
+	}
+
+	if p.value(p.g, p.start) >= dstarInfinity {
+		return nil, domain.ErrUnreachableExit
+	}
+
+	path := []domain.Point{p.start}
+
+	// Follow the neighbor closest to the goal
+	for current := p.start; current != p.goal; {
+		next, best := current, dstarInfinity
+
+		for _, n := range passageNeighbors(p.maze, current) {
+			if d := p.value(p.g, n); d < best {
+				next, best = n, d
+			}
+		}
+
+		if next == current {
+			return nil, domain.ErrUnreachableExit
+		}
+
+		current = next
+		path = append(path, current)
+	}
+
+	return path, nil
+}
+
+// MoveTo moves the agent to a new cell, typically the next cell of the current path.
+func (p *DStarLitePlanner) MoveTo(start domain.Point) error {
+	if err := p.maze.ValidateSolvePoints(start, p.goal); err != nil {
+		return err
+	}
+
+	// Keys of queued cells stay comparable by raising the bound for all future keys
+	p.km += p.heuristic(p.last, start)
+	p.last = start
+	p.start = start
+
+	p.computeShortestPath()
+
+	return nil
+}
+
+// SetWall opens or closes the cell and repairs the path.
+func (p *DStarLitePlanner) SetWall(cell domain.Point, wall bool) error {
+	if !p.maze.Contains(cell) {
+		return &domain.PointError{Name: "cell", Point: cell, Err: domain.ErrPointOutOfBounds}
+	}
+
+	if p.maze.Grid[cell.Y][cell.X].Wall == wall {
+		return nil
+	}
+
+	p.maze.Grid[cell.Y][cell.X].Wall = wall
+
+	// The cell and every neighbor may now have a different way to the goal
+	p.updateVertex(cell)
+
+	for _, dir := range clockwiseDirections {
+		if n := (domain.Point{X: cell.X + dir.X, Y: cell.Y + dir.Y}); p.maze.Contains(n) {
+			p.updateVertex(n)
+		}
+	}
+
+	p.computeShortestPath()
+
+	return nil
+}
+
+// computeShortestPath settles cells until the distance from the agent to the goal is known.
+func (p *DStarLitePlanner) computeShortestPath() {
+	for {
+		top, ok := p.top()
+
+		startKey := p.key(p.start)
+		if !ok || (!top.key.less(startKey) && p.value(p.rhs, p.start) == p.value(p.g, p.start)) {
+			return
+		}
+
+		heap.Pop(p.queue)
+
+		u := top.point
+		g, rhs := p.value(p.g, u), p.value(p.rhs, u)
+
+		switch newKey := p.key(u); {
+		case top.key.less(newKey):
+			p.push(u) // The key grew since the cell was queued
+		case g > rhs:
+			// The cell got closer to the goal: settle it and update the cells leading to it
+			p.g[u] = rhs
+			delete(p.open, u)
+
+			for _, n := range passageNeighbors(p.maze, u) {
+				p.updateVertex(n)
+			}
+		default:
+			// The cell got farther: reset it and recompute it along with the cells leading to it
+			p.g[u] = dstarInfinity
+			delete(p.open, u)
+			p.updateVertex(u)
+
+			for _, n := range passageNeighbors(p.maze, u) {
+				p.updateVertex(n)
+			}
+		}
+	}
+}
+
+// updateVertex recomputes the lookahead value of the cell and queues it if it is inconsistent.
+func (p *DStarLitePlanner) updateVertex(u domain.Point) {
+	if u != p.goal {
+		rhs := dstarInfinity
+
+		if !p.maze.Grid[u.Y][u.X].Wall {
+			for _, n := range passageNeighbors(p.maze, u) {
+				rhs = min(rhs, p.value(p.g, n)+1)
+			}
+		}
+
+		p.rhs[u] = rhs
+	}
+
+	delete(p.open, u)
+
+	if p.value(p.g, u) != p.value(p.rhs, u) {
+		p.push(u)
+	}
+}
+
+// push queues the cell with its current key.
+func (p *DStarLitePlanner) push(u domain.Point) {
+	key := p.key(u)
+	p.open[u] = key
+	heap.Push(p.queue, dstarEntry{point: u, key: key})
+}
+
+// top drops outdated entries and returns the entry with the smallest key.
+func (p *DStarLitePlanner) top() (dstarEntry, bool) {
+	for p.queue.Len() > 0 {
+		entry := (*p.queue)[0]
+		if key, ok := p.open[entry.point]; ok && key == entry.key {
+			return entry, true
+		}
+
+		heap.Pop(p.queue)
+	}
+
+	return dstarEntry{}, false
+}
+
+func (p *DStarLitePlanner) key(u domain.Point) dstarKey {
+	best := min(p.value(p.g, u), p.value(p.rhs, u))
+	return dstarKey{best + p.heuristic(p.start, u) + p.km, best}
+}
+
+// value returns the stored distance of the cell, infinity if there is none.
+func (p *DStarLitePlanner) value(values map[domain.Point]int, u domain.Point) int {
+	if v, ok := values[u]; ok {
+		return v
+	}
+
+	return dstarInfinity
+}
+
+// heuristic returns the Manhattan distance, which never overestimates on a 4-connected grid.
+func (p *DStarLitePlanner) heuristic(a, b domain.Point) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
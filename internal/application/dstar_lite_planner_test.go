@@ -0,0 +1,109 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// assertMatchesAStar checks that the planner's path is as short as a path found by A* from scratch.
+func assertMatchesAStar(t *testing.T, planner *application.DStarLitePlanner, maze *domain.Maze, start, goal domain.Point) {
+	t.Helper()
+
+	expected, expectedErr := (&application.AStarSolver{}).FindPath(maze, start, goal)
+	path, err := planner.Path()
+
+	if (err == nil) != (expectedErr == nil) {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if err != nil {
+		if !errors.Is(err, domain.ErrUnreachableExit) {
+			t.Fatalf("Expected ErrUnreachableExit, got %v", err)
+		}
+
+		return
+	}
+
+	assertValidPath(t, maze, path, start, goal)
+
+	if len(path) != len(expected) {
+		t.Fatalf("Expected path of %d cells, got %d", len(expected), len(path))
+	}
+}
+
+func TestDStarLitePlanner_MatchesAStarAfterChanges(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+
+	for run := 0; run < 5; run++ {
+		maze, start, goal := newBraidedMaze(t, 31, 150, rng)
+
+		planner, err := application.NewDStarLitePlanner(maze, start, goal)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertMatchesAStar(t, planner, maze, start, goal)
+
+		for change := 0; change < 200; change++ {
+			// Toggle an inner cell other than the agent and the goal
+			cell := domain.Point{X: 1 + rng.Intn(maze.Width-2), Y: 1 + rng.Intn(maze.Height-2)}
+			if cell == start || cell == goal {
+				continue
+			}
+
+			if err := planner.SetWall(cell, !maze.Grid[cell.Y][cell.X].Wall); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			assertMatchesAStar(t, planner, maze, start, goal)
+
+			// Now and then the agent walks a few steps along the current path
+			if path, err := planner.Path(); err == nil && change%10 == 0 && len(path) > 3 {
+				start = path[3]
+
+				if err := planner.MoveTo(start); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				assertMatchesAStar(t, planner, maze, start, goal)
+			}
+		}
+	}
+}
+
+func TestDStarLitePlanner_Errors(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+	start, goal := domain.Point{X: 0, Y: 0}, domain.Point{X: 4, Y: 4}
+
+	planner, err := application.NewDStarLitePlanner(maze, start, goal)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := planner.SetWall(domain.Point{X: 5, Y: 0}, true); !errors.Is(err, domain.ErrPointOutOfBounds) {
+		t.Errorf("Expected ErrPointOutOfBounds, got %v", err)
+	}
+
+	// Wall off the goal
+	for _, cell := range []domain.Point{{X: 3, Y: 4}, {X: 4, Y: 3}} {
+		if err := planner.SetWall(cell, true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if _, err := planner.Path(); !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+
+	if err := planner.SetWall(domain.Point{X: 3, Y: 4}, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if path, err := planner.Path(); err != nil || len(path) != 9 {
+		t.Errorf("Expected path of 9 cells after reopening, got %v (%v)", path, err)
+	}
+}
@@ -0,0 +1,123 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// Unreachable is the distance of walls and cells from which the target cannot be reached.
+const Unreachable = -1
+
+// FlowField holds, for every cell of the maze, the number of moves to the target and the cell
+// to step to next, so any number of agents can walk to the target without searching.
+type FlowField struct {
+	Target    domain.Point
+	Distances [][]int
+	// Next is the cell to step to from every cell; the target and unreachable cells point to themselves.
+	Next [][]domain.Point
+}
+
+// FlowFieldBuilder computes flow fields with a single BFS run backwards from the target.
+// One-way passages and teleporters are followed in reverse, so the field leads along moves
+// the solvers would make.
+type FlowFieldBuilder struct{}
+
+// NewFlowFieldBuilder initializes the FlowFieldBuilder.
+func NewFlowFieldBuilder() *FlowFieldBuilder {
+	return &FlowFieldBuilder{}
+}
+
+// Build computes the flow field leading to the target.
+func (b *FlowFieldBuilder) Build(maze *domain.Maze, target domain.Point) (*FlowField, error) {
+	if err := maze.ValidateSolvePoints(target, target); err != nil {
+		return nil, err
+	}
+
+	field := &FlowField{
+		Target:    target,
+		Distances: make([][]int, maze.Height),
+		Next:      make([][]domain.Point, maze.Height),
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		field.Distances[y] = make([]int, maze.Width)
+		field.Next[y] = make([]domain.Point, maze.Width)
+
+		for x := 0; x < maze.Width; x++ {
+			field.Distances[y][x] = Unreachable
+			field.Next[y][x] = domain.Point{X: x, Y: y}
+		}
+	}
+
+	field.Distances[target.Y][target.X] = 0
+	queue := []domain.Point{target}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, previous := range b.previousMoves(maze, current) {
+			if field.Distances[previous.Y][previous.X] != Unreachable {
+				continue
+			}
+
+			field.Distances[previous.Y][previous.X] = field.Distances[current.Y][current.X] + 1
+			field.Next[previous.Y][previous.X] = current
+			queue = append(queue, previous)
+		}
+	}
+
+	return field, nil
+}
+
+// previousMoves returns the cells from which the point can be reached in a single move.
+func (b *FlowFieldBuilder) previousMoves(maze *domain.Maze, p domain.Point) []domain.Point {
+	var moves []domain.Point
+
+	for _, n := range passageNeighbors(maze, p) {
+		if maze.CanStep(n, p) {
+			moves = append(moves, n)
+		}
+	}
+
+	// Teleporters work both ways
+	if source, ok := maze.TeleportTarget(p); ok {
+		moves = append(moves, source)
+	}
+
+	return moves
+}
+
+// Distance returns the number of moves from the cell to the target, or Unreachable.
+func (f *FlowField) Distance(p domain.Point) int {
+	if p.Y < 0 || p.Y >= len(f.Distances) || p.X < 0 || p.X >= len(f.Distances[p.Y]) {
+		return Unreachable
+	}
+
+	return f.Distances[p.Y][p.X]
+}
+
+// MaxDistance returns the largest distance to the target among reachable cells.
+func (f *FlowField) MaxDistance() int {
+	longest := 0
+
+	for _, row := range f.Distances {
+		for _, d := range row {
+			longest = max(longest, d)
+		}
+	}
+
+	return longest
+}
+
+// PathFrom follows the field from the cell to the target.
+func (f *FlowField) PathFrom(p domain.Point) ([]domain.Point, error) {
+	if f.Distance(p) == Unreachable {
+		return nil, domain.ErrUnreachableExit
+	}
+
+	path := []domain.Point{p}
+	for p != f.Target {
+		p = f.Next[p.Y][p.X]
+		path = append(path, p)
+	}
+
+	return path, nil
+}
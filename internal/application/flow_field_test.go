@@ -0,0 +1,113 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestFlowFieldBuilder_Build_MatchesBFS(t *testing.T) {
+	maze, _, exit := newBraidedMaze(t, 21, 40, rand.New(rand.NewSource(19)))
+
+	field, err := application.NewFlowFieldBuilder().Build(maze, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			p := domain.Point{X: x, Y: y}
+
+			if maze.Grid[y][x].Wall {
+				if field.Distance(p) != application.Unreachable {
+					t.Fatalf("Expected wall %v to be unreachable, got %d", p, field.Distance(p))
+				}
+
+				continue
+			}
+
+			// Removed walls may leave isolated cells behind
+			expected, err := (&application.BFSSolver{}).FindPath(maze, p, exit)
+			if errors.Is(err, domain.ErrUnreachableExit) {
+				if field.Distance(p) != application.Unreachable {
+					t.Fatalf("Expected isolated cell %v to be unreachable, got %d", p, field.Distance(p))
+				}
+
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if d := field.Distance(p); d != len(expected)-1 {
+				t.Fatalf("Expected distance %d from %v, got %d", len(expected)-1, p, d)
+			}
+
+			path, err := field.PathFrom(p)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			assertValidPath(t, maze, path, p, exit)
+
+			if len(path) != len(expected) {
+				t.Fatalf("Expected path of %d cells from %v, got %d", len(expected), p, len(path))
+			}
+		}
+	}
+}
+
+func TestFlowFieldBuilder_Build_OneWayPassage(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#...#",
+		"#.#.#",
+		"#...#",
+		"#.###",
+	)
+	exit := domain.Point{X: 1, Y: 4}
+
+	// Only walking up is allowed in the left corridor
+	if err := maze.SetOneWay(domain.Point{X: 1, Y: 2}, domain.Point{Y: -1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	field, err := application.NewFlowFieldBuilder().Build(maze, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// From the top the way down goes around through the right corridor
+	if d := field.Distance(domain.Point{X: 1, Y: 1}); d != 7 {
+		t.Errorf("Expected distance 7 around the one-way passage, got %d", d)
+	}
+
+	if next := field.Next[1][1]; next != (domain.Point{X: 2, Y: 1}) {
+		t.Errorf("Expected to step right first, got %v", next)
+	}
+}
+
+func TestFlowField_PathFrom_Unreachable(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#.#.#",
+		"#.###",
+	)
+
+	field, err := application.NewFlowFieldBuilder().Build(maze, domain.Point{X: 1, Y: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := field.PathFrom(domain.Point{X: 3, Y: 1}); !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+
+	if _, err := application.NewFlowFieldBuilder().Build(maze, domain.Point{X: 0, Y: 0}); !errors.Is(err, domain.ErrPointInWall) {
+		t.Errorf("Expected ErrPointInWall, got %v", err)
+	}
+}
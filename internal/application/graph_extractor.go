@@ -0,0 +1,121 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// NodeKind describes the role of a node in the maze graph.
+type NodeKind int
+
+const (
+	// DeadEnd is a passage cell with a single open neighbor (entry and exit openings are dead ends too).
+	DeadEnd NodeKind = iota
+	// Junction is a passage cell with three or more open neighbors.
+	Junction
+	// Isolated is a passage cell without open neighbors.
+	Isolated
+)
+
+// GraphNode is a junction or a dead end of the maze.
+type GraphNode struct {
+	Point  domain.Point
+	Kind   NodeKind
+	Degree int
+}
+
+// GraphEdge is a corridor between two nodes. Cells holds the intermediate corridor cells
+// and Length is the number of steps from one node to the other.
+type GraphEdge struct {
+	From   domain.Point
+	To     domain.Point
+	Length int
+	Cells  []domain.Point
+}
+
+// MazeGraph is a condensed representation of the maze topology.
+type MazeGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphExtractor builds a MazeGraph from the grid of a maze.
+type GraphExtractor struct{}
+
+// NewGraphExtractor initializes the GraphExtractor.
+func NewGraphExtractor() *GraphExtractor {
+	return &GraphExtractor{}
+}
+
+// Extract collapses corridors of the maze into weighted edges between junctions and dead ends.
+func (e *GraphExtractor) Extract(maze *domain.Maze) *MazeGraph {
+	graph := &MazeGraph{}
+	nodes := make(map[domain.Point]bool)
+
+	// Every passage cell that is not a plain corridor cell becomes a node
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			p := domain.Point{X: x, Y: y}
+			if maze.Grid[y][x].Wall {
+				continue
+			}
+
+			degree := len(passageNeighbors(maze, p))
+			if degree == 2 {
+				continue
+			}
+
+			kind := Junction
+
+			switch degree {
+			case 0:
+				kind = Isolated
+			case 1:
+				kind = DeadEnd
+			}
+
+			nodes[p] = true
+			graph.Nodes = append(graph.Nodes, GraphNode{Point: p, Kind: kind, Degree: degree})
+		}
+	}
+
+	// Corridor ends already consumed by a walk from the opposite node
+	type corridorEnd struct{ node, first domain.Point }
+
+	used := make(map[corridorEnd]bool)
+
+	for _, node := range graph.Nodes {
+		for _, first := range passageNeighbors(maze, node.Point) {
+			if used[corridorEnd{node.Point, first}] {
+				continue
+			}
+
+			// Walk along the corridor until the next node
+			var cells []domain.Point
+
+			prev, current := node.Point, first
+			for !nodes[current] {
+				cells = append(cells, current)
+
+				next := current
+				for _, n := range passageNeighbors(maze, current) {
+					if n != prev {
+						next = n
+						break
+					}
+				}
+
+				prev, current = current, next
+			}
+
+			used[corridorEnd{node.Point, first}] = true
+			used[corridorEnd{current, prev}] = true
+
+			graph.Edges = append(graph.Edges, GraphEdge{
+				From:   node.Point,
+				To:     current,
+				Length: len(cells) + 1,
+				Cells:  cells,
+			})
+		}
+	}
+
+	return graph
+}
@@ -0,0 +1,79 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// newMazeFromRows builds a maze from text rows where '#' is a wall and any other character is a passage.
+func newMazeFromRows(rows ...string) *domain.Maze {
+	maze := domain.NewMaze(len(rows[0]), len(rows))
+
+	for y, row := range rows {
+		for x, c := range row {
+			maze.Grid[y][x].Wall = c == '#'
+		}
+	}
+
+	return maze
+}
+
+func TestGraphExtractor_Extract_SimpleMaze(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#...#",
+		"#.#.#",
+		"#.#.#",
+		"###.#",
+	)
+
+	graph := application.NewGraphExtractor().Extract(maze)
+
+	if len(graph.Nodes) != 4 {
+		t.Fatalf("Expected 4 nodes, got %d", len(graph.Nodes))
+	}
+
+	if len(graph.Edges) != 3 {
+		t.Fatalf("Expected 3 edges, got %d", len(graph.Edges))
+	}
+
+	junctions := 0
+
+	for _, node := range graph.Nodes {
+		if node.Kind == application.Junction {
+			junctions++
+
+			if node.Point != (domain.Point{X: 1, Y: 1}) {
+				t.Errorf("Expected junction at (1,1), got %v", node.Point)
+			}
+		}
+	}
+
+	if junctions != 1 {
+		t.Errorf("Expected 1 junction, got %d", junctions)
+	}
+
+	totalLength := 0
+	for _, edge := range graph.Edges {
+		totalLength += edge.Length
+	}
+
+	if totalLength != 8 {
+		t.Errorf("Expected total corridor length 8, got %d", totalLength)
+	}
+}
+
+func TestGraphExtractor_Extract_PerfectMazeIsTree(t *testing.T) {
+	maze := domain.NewMaze(21, 21)
+	if err := (&application.KruskalGenerator{}).Generate(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 19, Y: 20}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	graph := application.NewGraphExtractor().Extract(maze)
+
+	if len(graph.Edges) != len(graph.Nodes)-1 {
+		t.Errorf("Expected a tree with %d edges, got %d", len(graph.Nodes)-1, len(graph.Edges))
+	}
+}
@@ -0,0 +1,269 @@
+package application
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// Movement costs scaled by 10 so that diagonal steps (≈ √2) stay integer.
+const (
+	straightCost = 10
+	diagonalCost = 14
+)
+
+// JPSSolver implements Jump Point Search on uniform-cost grids. Instead of expanding every
+// cell like AStarSolver, it jumps along straight lines and only stops at cells where the
+// path may need to turn, which makes it much faster on open layouts.
+type JPSSolver struct {
+	// Diagonal enables 8-connected movement. Diagonal steps are only allowed when both
+	// adjacent orthogonal cells are passages, so paths never cut corners.
+	Diagonal bool
+}
+
+func (s *JPSSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(context.Background(), maze, entry, exit, &searchTrace{})
+}
+
+// FindPathContext finds the path, stopping early if the context is done.
+func (s *JPSSolver) FindPathContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(ctx, maze, entry, exit, &searchTrace{})
+}
+
+// FindPathWithStats finds the path and reports the work done by the search.
+// Only jump points are expanded, so the visited set holds the jump points reached.
+func (s *JPSSolver) FindPathWithStats(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, domain.SearchStats, error) {
+	return collectStats(func(trace *searchTrace) ([]domain.Point, error) {
+		return s.search(context.Background(), maze, entry, exit, trace)
+	})
+}
+
+// search runs the jump point search recording its work in the trace.
+func (s *JPSSolver) search(ctx context.Context, maze *domain.Maze, entry, exit domain.Point, trace *searchTrace) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	checker := newContextChecker(ctx)
+
+	// Initialize priority queue
+	pq := &PriorityQueue{}
+	heap.Init(pq)
+	heap.Push(pq, &Node{Point: entry, Priority: s.heuristic(entry, exit)})
+
+	best := map[domain.Point]int{entry: 0}
+	closed := make(map[domain.Point]bool)
+	trace.visited = visitedFrom(best)
+
+	for pq.Len() > 0 {
+		if err := checker.check(); err != nil {
+			return nil, err
+		}
+
+		frontier := pq.Len()
+
+		currentNode := heap.Pop(pq).(*Node)
+		if closed[currentNode.Point] {
+			continue // Outdated queue entry
+		}
+
+		trace.expand(frontier)
+
+		closed[currentNode.Point] = true
+
+		if currentNode.Point == exit {
+			return s.buildPath(currentNode), nil
+		}
+
+		// Jump in every direction that is not pruned and queue the jump points
+		for _, dir := range s.prunedDirections(maze, currentNode) {
+			jumpPoint, ok := s.jump(maze, currentNode.Point, dir, exit)
+			if !ok || closed[jumpPoint] {
+				continue
+			}
+
+			newCost := currentNode.Cost + s.distance(currentNode.Point, jumpPoint)
+			if cost, seen := best[jumpPoint]; seen && cost <= newCost {
+				continue
+			}
+
+			best[jumpPoint] = newCost
+			heap.Push(pq, &Node{
+				Point:    jumpPoint,
+				Cost:     newCost,
+				Priority: newCost + s.heuristic(jumpPoint, exit),
+				Parent:   currentNode,
+			})
+		}
+	}
+
+	// Path not found
+	return nil, domain.ErrUnreachableExit
+}
+
+// jump moves from the point in the direction until it reaches a jump point, the exit or an obstacle.
+func (s *JPSSolver) jump(maze *domain.Maze, from, dir, exit domain.Point) (domain.Point, bool) {
+	current := from
+
+	for {
+		// Diagonal steps require both orthogonal cells to be free
+		if dir.X != 0 && dir.Y != 0 &&
+			(!s.walkable(maze, current.X+dir.X, current.Y) || !s.walkable(maze, current.X, current.Y+dir.Y)) {
+			return domain.Point{}, false
+		}
+
+		current = domain.Point{X: current.X + dir.X, Y: current.Y + dir.Y}
+
+		if !s.walkable(maze, current.X, current.Y) {
+			return domain.Point{}, false
+		}
+
+		if current == exit || s.isJumpPoint(maze, current, dir, exit) {
+			return current, true
+		}
+	}
+}
+
+// isJumpPoint checks whether the cell reached in the direction has forced neighbors
+// or leads to a jump point in one of the perpendicular directions.
+func (s *JPSSolver) isJumpPoint(maze *domain.Maze, p, dir, exit domain.Point) bool {
+	x, y := p.X, p.Y
+
+	switch {
+	case dir.X != 0 && dir.Y != 0:
+		// Diagonal: horizontal or vertical jumps lead somewhere
+		return s.hasJump(maze, p, domain.Point{X: dir.X}, exit) || s.hasJump(maze, p, domain.Point{Y: dir.Y}, exit)
+	case dir.X != 0:
+		// Horizontal: an opening above or below that was closed one step back
+		return (s.walkable(maze, x, y-1) && !s.walkable(maze, x-dir.X, y-1)) ||
+			(s.walkable(maze, x, y+1) && !s.walkable(maze, x-dir.X, y+1))
+	case s.Diagonal:
+		// Vertical with diagonal movement: an opening to the side that was closed one step back
+		return (s.walkable(maze, x-1, y) && !s.walkable(maze, x-1, y-dir.Y)) ||
+			(s.walkable(maze, x+1, y) && !s.walkable(maze, x+1, y-dir.Y))
+	default:
+		// Vertical on a 4-connected grid: turning sideways leads somewhere
+		return s.hasJump(maze, p, domain.Point{X: 1}, exit) || s.hasJump(maze, p, domain.Point{X: -1}, exit)
+	}
+}
+
+// hasJump checks whether a jump from the point in the direction finds a jump point.
+func (s *JPSSolver) hasJump(maze *domain.Maze, from, dir, exit domain.Point) bool {
+	_, ok := s.jump(maze, from, dir, exit)
+	return ok
+}
+
+// prunedDirections returns the directions worth exploring from the node given the direction it was reached from.
+func (s *JPSSolver) prunedDirections(maze *domain.Maze, node *Node) []domain.Point {
+	if node.Parent == nil {
+		directions := []domain.Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+		if s.Diagonal {
+			directions = append(directions, domain.Point{X: 1, Y: -1}, domain.Point{X: 1, Y: 1},
+				domain.Point{X: -1, Y: 1}, domain.Point{X: -1, Y: -1})
+		}
+
+		return directions
+	}
+
+	x, y := node.Point.X, node.Point.Y
+	dx := sign(x - node.Parent.Point.X)
+	dy := sign(y - node.Parent.Point.Y)
+
+	var directions []domain.Point
+
+	switch {
+	case dx != 0 && dy != 0:
+		// Diagonal: both orthogonal components and the diagonal itself
+		directions = append(directions, domain.Point{X: dx}, domain.Point{Y: dy}, domain.Point{X: dx, Y: dy})
+	case dx != 0:
+		directions = append(directions, domain.Point{X: dx})
+
+		for _, side := range []int{-1, 1} {
+			if !s.walkable(maze, x, y+side) {
+				continue
+			}
+
+			if s.Diagonal {
+				// Sides are natural neighbors when moving diagonally is allowed
+				directions = append(directions, domain.Point{Y: side}, domain.Point{X: dx, Y: side})
+			} else if !s.walkable(maze, x-dx, y+side) {
+				directions = append(directions, domain.Point{Y: side}) // Forced neighbor
+			}
+		}
+	default:
+		directions = append(directions, domain.Point{Y: dy}, domain.Point{X: 1}, domain.Point{X: -1})
+
+		if s.Diagonal {
+			directions = append(directions, domain.Point{X: 1, Y: dy}, domain.Point{X: -1, Y: dy})
+		}
+	}
+
+	return directions
+}
+
+// buildPath expands the straight segments between consecutive jump points into single steps.
+func (s *JPSSolver) buildPath(node *Node) []domain.Point {
+	var jumpPoints []domain.Point
+	for n := node; n != nil; n = n.Parent {
+		jumpPoints = append([]domain.Point{n.Point}, jumpPoints...)
+	}
+
+	path := []domain.Point{jumpPoints[0]}
+
+	for i := 1; i < len(jumpPoints); i++ {
+		current, target := jumpPoints[i-1], jumpPoints[i]
+		dx, dy := sign(target.X-current.X), sign(target.Y-current.Y)
+
+		for current != target {
+			current = domain.Point{X: current.X + dx, Y: current.Y + dy}
+			path = append(path, current)
+		}
+	}
+
+	return path
+}
+
+// distance returns the cost of moving along a straight or diagonal segment between two points.
+func (s *JPSSolver) distance(a, b domain.Point) int {
+	return octileDistance(a, b)
+}
+
+// heuristic returns the Manhattan distance for 4-connected grids and the octile distance otherwise.
+func (s *JPSSolver) heuristic(a, b domain.Point) int {
+	if s.Diagonal {
+		return octileDistance(a, b)
+	}
+
+	return straightCost * (abs(a.X-b.X) + abs(a.Y-b.Y))
+}
+
+// walkable checks that the cell is within the maze bounds and is a passage.
+func (s *JPSSolver) walkable(maze *domain.Maze, x, y int) bool {
+	return x >= 0 && x < maze.Width && y >= 0 && y < maze.Height && !maze.Grid[y][x].Wall
+}
+
+// octileDistance returns the cost of the cheapest 8-connected route between two points on an empty grid.
+func octileDistance(a, b domain.Point) int {
+	dx, dy := abs(a.X-b.X), abs(a.Y-b.Y)
+	return straightCost*(dx+dy) + (diagonalCost-2*straightCost)*min(dx, dy)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,181 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// newRandomGrid creates an open grid with randomly blocked cells, keeping the corners free.
+func newRandomGrid(width, height int, density float64, rng *rand.Rand) *domain.Maze {
+	maze := domain.NewMaze(width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			maze.Grid[y][x].Wall = rng.Float64() < density
+		}
+	}
+
+	maze.Grid[0][0].Wall = false
+	maze.Grid[height-1][width-1].Wall = false
+
+	return maze
+}
+
+// octilePathCost sums the step costs of an 8-connected path.
+func octilePathCost(path []domain.Point) int {
+	cost := 0
+
+	for i := 1; i < len(path); i++ {
+		if path[i].X != path[i-1].X && path[i].Y != path[i-1].Y {
+			cost += 14
+		} else {
+			cost += 10
+		}
+	}
+
+	return cost
+}
+
+// dijkstraOctileCost is a brute-force reference for the cheapest 8-connected route without corner cutting.
+func dijkstraOctileCost(maze *domain.Maze, entry, exit domain.Point) int {
+	free := func(x, y int) bool {
+		return x >= 0 && x < maze.Width && y >= 0 && y < maze.Height && !maze.Grid[y][x].Wall
+	}
+
+	dist := map[domain.Point]int{entry: 0}
+	done := make(map[domain.Point]bool)
+
+	for {
+		current, found := domain.Point{}, false
+
+		for p, d := range dist {
+			if !done[p] && (!found || d < dist[current]) {
+				current, found = p, true
+			}
+		}
+
+		if !found {
+			return -1
+		}
+
+		if current == exit {
+			return dist[current]
+		}
+
+		done[current] = true
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				next := domain.Point{X: current.X + dx, Y: current.Y + dy}
+				if (dx == 0 && dy == 0) || !free(next.X, next.Y) {
+					continue
+				}
+
+				step := 10
+				if dx != 0 && dy != 0 {
+					if !free(current.X+dx, current.Y) || !free(current.X, current.Y+dy) {
+						continue
+					}
+
+					step = 14
+				}
+
+				if d, ok := dist[next]; !ok || dist[current]+step < d {
+					dist[next] = dist[current] + step
+				}
+			}
+		}
+	}
+}
+
+func TestJPSSolver_FindPath_MatchesBFSOnMazes(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 30; i++ {
+		maze, entry, exit := newBraidedMaze(t, 21, rng.Intn(80), rng)
+
+		expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+		path, err := (&application.JPSSolver{}).FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+
+		if len(path) != len(expected) {
+			t.Fatalf("Expected path of length %d, got %d", len(expected), len(path))
+		}
+	}
+}
+
+func TestJPSSolver_FindPath_MatchesBFSOnOpenGrids(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 200; i++ {
+		maze := newRandomGrid(4+rng.Intn(12), 4+rng.Intn(12), rng.Float64()*0.4, rng)
+		entry := domain.Point{X: 0, Y: 0}
+		exit := domain.Point{X: maze.Width - 1, Y: maze.Height - 1}
+
+		expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+		path, err := (&application.JPSSolver{}).FindPath(maze, entry, exit)
+
+		if expected == nil {
+			if !errors.Is(err, domain.ErrUnreachableExit) {
+				t.Fatalf("Expected ErrUnreachableExit, got %v", err)
+			}
+
+			continue
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+
+		if len(path) != len(expected) {
+			t.Fatalf("Expected path of length %d, got %d", len(expected), len(path))
+		}
+	}
+}
+
+func TestJPSSolver_FindPath_Diagonal(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+
+	for i := 0; i < 200; i++ {
+		maze := newRandomGrid(4+rng.Intn(10), 4+rng.Intn(10), rng.Float64()*0.4, rng)
+		entry := domain.Point{X: 0, Y: 0}
+		exit := domain.Point{X: maze.Width - 1, Y: maze.Height - 1}
+
+		expected := dijkstraOctileCost(maze, entry, exit)
+		path, err := (&application.JPSSolver{Diagonal: true}).FindPath(maze, entry, exit)
+
+		if expected < 0 {
+			if !errors.Is(err, domain.ErrUnreachableExit) {
+				t.Fatalf("Expected ErrUnreachableExit, got %v", err)
+			}
+
+			continue
+		}
+
+		if len(path) == 0 || path[0] != entry || path[len(path)-1] != exit {
+			t.Fatalf("Expected path from %v to %v, got %v", entry, exit, path)
+		}
+
+		for j := 1; j < len(path); j++ {
+			prev, p := path[j-1], path[j]
+			if maze.Grid[p.Y][p.X].Wall {
+				t.Fatalf("Path goes through a wall at %v", p)
+			}
+
+			if p.X != prev.X && p.Y != prev.Y && (maze.Grid[prev.Y][p.X].Wall || maze.Grid[p.Y][prev.X].Wall) {
+				t.Fatalf("Path cuts a corner between %v and %v", prev, p)
+			}
+		}
+
+		if cost := octilePathCost(path); cost != expected {
+			t.Fatalf("Expected path cost %d, got %d", expected, cost)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// ErrInvalidPathLimit is returned when fewer than one path is requested.
+var ErrInvalidPathLimit = errors.New("number of paths must be positive")
+
+// KShortestPathsSolver finds the k shortest simple paths with Yen's algorithm. Every next path
+// is the shortest deviation from one of the paths found so far: the route follows a found path
+// up to a spur cell and then takes the shortest way to the exit that avoids the cells before the
+// spur and the moves already taken from it by paths sharing the same beginning.
+type KShortestPathsSolver struct{}
+
+// NewKShortestPathsSolver initializes the KShortestPathsSolver.
+func NewKShortestPathsSolver() *KShortestPathsSolver {
+	return &KShortestPathsSolver{}
+}
+
+// move is a single step between two cells.
+type move struct{ from, to domain.Point }
+
+// FindPaths returns up to k shortest simple paths ordered by length.
+func (s *KShortestPathsSolver) FindPaths(maze *domain.Maze, entry, exit domain.Point, k int) ([][]domain.Point, error) {
+	if k <= 0 {
+		return nil, ErrInvalidPathLimit
+	}
+
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	first := s.shortestPath(maze, entry, exit, nil, nil)
+	if first == nil {
+		return nil, domain.ErrUnreachableExit
+	}
+
+	paths := [][]domain.Point{first}
+
+	var candidates [][]domain.Point
+
+	for len(paths) < k {
+		previous := paths[len(paths)-1]
+
+		for i := 0; i < len(previous)-1; i++ {
+			spur, root := previous[i], previous[:i+1]
+
+			// Forbid the moves out of the spur already taken by paths with the same root
+			blockedMoves := make(map[move]bool)
+
+			for _, path := range paths {
+				if len(path) > i+1 && slices.Equal(path[:i+1], root) {
+					blockedMoves[move{path[i], path[i+1]}] = true
+				}
+			}
+
+			// Forbid the root cells so the path stays simple
+			blockedCells := make(map[domain.Point]bool)
+			for _, p := range root[:i] {
+				blockedCells[p] = true
+			}
+
+			spurPath := s.shortestPath(maze, spur, exit, blockedCells, blockedMoves)
+			if spurPath == nil {
+				continue
+			}
+
+			candidate := append(slices.Clone(root[:i]), spurPath...)
+			if !s.contains(candidates, candidate) && !s.contains(paths, candidate) {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Take the shortest candidate, the earliest found among equals
+		best := 0
+		for i, c := range candidates {
+			if len(c) < len(candidates[best]) {
+				best = i
+			}
+		}
+
+		paths = append(paths, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	return paths, nil
+}
+
+// shortestPath runs BFS avoiding the blocked cells and moves, returning nil if the exit is unreachable.
+func (s *KShortestPathsSolver) shortestPath(maze *domain.Maze, entry, exit domain.Point, blockedCells map[domain.Point]bool,
+	blockedMoves map[move]bool) []domain.Point {
+	queue := []domain.Point{entry}
+	visited := map[domain.Point]bool{entry: true}
+	parent := make(map[domain.Point]domain.Point)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == exit {
+			path := []domain.Point{exit}
+			for p := exit; p != entry; {
+				p = parent[p]
+				path = append(path, p)
+			}
+
+			slices.Reverse(path)
+
+			return path
+		}
+
+		for _, neighbor := range nextMoves(maze, current, false) {
+			if visited[neighbor] || blockedCells[neighbor] || blockedMoves[move{current, neighbor}] {
+				continue
+			}
+
+			visited[neighbor] = true
+			parent[neighbor] = current
+			queue = append(queue, neighbor)
+		}
+	}
+
+	return nil
+}
+
+// contains checks whether the path is among the paths.
+func (s *KShortestPathsSolver) contains(paths [][]domain.Point, path []domain.Point) bool {
+	for _, p := range paths {
+		if slices.Equal(p, path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package application_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// assertDistinctSimplePaths checks that every path is valid, visits no cell twice and differs from the others.
+func assertDistinctSimplePaths(t *testing.T, maze *domain.Maze, paths [][]domain.Point, entry, exit domain.Point) {
+	t.Helper()
+
+	seen := make(map[string]bool)
+
+	for _, path := range paths {
+		assertValidPath(t, maze, path, entry, exit)
+
+		cells := make(map[domain.Point]bool)
+		for _, p := range path {
+			if cells[p] {
+				t.Fatalf("Expected a simple path, %v is visited twice", p)
+			}
+
+			cells[p] = true
+		}
+
+		key := fmt.Sprint(path)
+		if seen[key] {
+			t.Fatalf("Expected distinct paths, got %v twice", path)
+		}
+
+		seen[key] = true
+	}
+}
+
+func TestBFSSolver_FindAllShortestPaths_OpenGrid(t *testing.T) {
+	maze := domain.NewMaze(3, 3)
+	entry, exit := domain.Point{X: 0, Y: 0}, domain.Point{X: 2, Y: 2}
+
+	paths, err := (&application.BFSSolver{}).FindAllShortestPaths(maze, entry, exit, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Two moves right and two moves down in any order
+	if len(paths) != 6 {
+		t.Fatalf("Expected 6 shortest paths, got %d", len(paths))
+	}
+
+	assertDistinctSimplePaths(t, maze, paths, entry, exit)
+
+	for _, path := range paths {
+		if len(path) != 5 {
+			t.Errorf("Expected path of 5 cells, got %v", path)
+		}
+	}
+
+	paths, err = (&application.BFSSolver{}).FindAllShortestPaths(maze, entry, exit, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(paths) != 4 {
+		t.Errorf("Expected the limit of 4 paths, got %d", len(paths))
+	}
+}
+
+func TestKShortestPathsSolver_FindPaths_OpenGrid(t *testing.T) {
+	maze := domain.NewMaze(3, 3)
+	entry, exit := domain.Point{X: 0, Y: 0}, domain.Point{X: 2, Y: 2}
+
+	paths, err := application.NewKShortestPathsSolver().FindPaths(maze, entry, exit, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(paths) != 8 {
+		t.Fatalf("Expected 8 paths, got %d", len(paths))
+	}
+
+	assertDistinctSimplePaths(t, maze, paths, entry, exit)
+
+	// Six shortest paths of 5 cells, then the detours of 7 cells
+	for i, path := range paths {
+		expected := 5
+		if i >= 6 {
+			expected = 7
+		}
+
+		if len(path) != expected {
+			t.Errorf("Expected path %d of %d cells, got %d", i+1, expected, len(path))
+		}
+	}
+}
+
+func TestKShortestPathsSolver_FindPaths_MatchesAllShortest(t *testing.T) {
+	rng := rand.New(rand.NewSource(15))
+
+	for i := 0; i < 20; i++ {
+		maze, entry, exit := newBraidedMaze(t, 15, 30, rng)
+
+		shortest, err := (&application.BFSSolver{}).FindAllShortestPaths(maze, entry, exit, 50)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(shortest) == 50 {
+			continue // Too many to compare
+		}
+
+		paths, err := application.NewKShortestPathsSolver().FindPaths(maze, entry, exit, len(shortest)+3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertDistinctSimplePaths(t, maze, paths, entry, exit)
+
+		for j, path := range paths {
+			if j > 0 && len(path) < len(paths[j-1]) {
+				t.Fatalf("Expected paths ordered by length, got %d after %d", len(path), len(paths[j-1]))
+			}
+
+			if onShortest := len(path) == len(shortest[0]); onShortest != (j < len(shortest)) {
+				t.Fatalf("Expected exactly %d shortest paths of %d cells, path %d has %d", len(shortest), len(shortest[0]), j+1, len(path))
+			}
+		}
+	}
+}
+
+func TestKShortestPathsSolver_FindPaths_Errors(t *testing.T) {
+	maze := domain.NewMaze(3, 3)
+	entry, exit := domain.Point{X: 0, Y: 0}, domain.Point{X: 2, Y: 2}
+
+	if _, err := application.NewKShortestPathsSolver().FindPaths(maze, entry, exit, 0); !errors.Is(err, application.ErrInvalidPathLimit) {
+		t.Errorf("Expected ErrInvalidPathLimit, got %v", err)
+	}
+
+	if _, err := (&application.BFSSolver{}).FindAllShortestPaths(maze, entry, exit, -1); !errors.Is(err, application.ErrInvalidPathLimit) {
+		t.Errorf("Expected ErrInvalidPathLimit, got %v", err)
+	}
+
+	maze.Grid[1][2].Wall = true
+	maze.Grid[2][1].Wall = true
+
+	if _, err := application.NewKShortestPathsSolver().FindPaths(maze, entry, exit, 3); !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// keyDoorMaxAttempts limits how many door layouts are tried before giving up.
+const keyDoorMaxAttempts = 100
+
+// ErrTooManyDoors is returned when the doors and their keys do not fit into the maze.
+var ErrTooManyDoors = errors.New("not enough room for the requested doors and keys")
+
+// KeyDoorGenerator generates a maze with the base generator and then places colored doors on
+// the way from the entry to the exit. The key of every door is placed where it can be reached
+// with the keys of the previous doors only, so the maze always stays solvable.
+type KeyDoorGenerator struct {
+	Base  domain.Generator
+	Doors int
+	// Rand places the doors and keys; the global source is used if nil.
+	Rand *rand.Rand
+}
+
+// NewKeyDoorGenerator initializes the KeyDoorGenerator.
+func NewKeyDoorGenerator(base domain.Generator, doors int) *KeyDoorGenerator {
+	return &KeyDoorGenerator{Base: base, Doors: doors}
+}
+
+// Generate creates a maze with the base generator and places the doors and keys.
+func (g *KeyDoorGenerator) Generate(maze *domain.Maze, entry, exit domain.Point) error {
+	if err := g.Base.Generate(maze, entry, exit); err != nil {
+		return err
+	}
+
+	return g.PlaceItems(maze, entry, exit)
+}
+
+// PlaceItems places the doors and keys into an already generated maze.
+func (g *KeyDoorGenerator) PlaceItems(maze *domain.Maze, entry, exit domain.Point) error {
+	if g.Doors < 0 || g.Doors > domain.MaxItemColors {
+		return fmt.Errorf("%w: %d doors, at most %d colors", ErrTooManyDoors, g.Doors, domain.MaxItemColors)
+	}
+
+	path, err := (&BFSSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		return err
+	}
+
+	// Doors never go right next to the entry, so the first key always has room
+	if len(path) < 3 || len(path)-3 < g.Doors {
+		return fmt.Errorf("%w: path of %d cells", ErrTooManyDoors, len(path))
+	}
+
+	candidates := path[2 : len(path)-1]
+
+	for attempt := 0; attempt < keyDoorMaxAttempts; attempt++ {
+		maze.ClearItems()
+
+		// Doors are colored in the order they appear on the path
+		indices := g.perm(len(candidates))[:g.Doors]
+		sort.Ints(indices)
+
+		for color, i := range indices {
+			maze.SetItem(candidates[i], domain.Item{Kind: domain.DoorItem, Color: color})
+		}
+
+		if g.placeKeys(maze, entry, exit) {
+			return nil
+		}
+	}
+
+	maze.ClearItems()
+
+	return ErrTooManyDoors
+}
+
+// placeKeys puts the key of every door somewhere reachable without passing that door or any later one.
+func (g *KeyDoorGenerator) placeKeys(maze *domain.Maze, entry, exit domain.Point) bool {
+	for color := 0; color < g.Doors; color++ {
+		var free []domain.Point
+
+		for _, p := range g.reachableBefore(maze, entry, color) {
+			if p != entry && p != exit && maze.ItemAt(p).Kind == domain.NoItem {
+				free = append(free, p)
+			}
+		}
+
+		if len(free) == 0 {
+			return false
+		}
+
+		key := free[g.intn(len(free))]
+		maze.SetItem(key, domain.Item{Kind: domain.KeyItem, Color: color})
+	}
+
+	return true
+}
+
+// reachableBefore returns the cells reachable from the entry treating doors of the color and later as walls.
+func (g *KeyDoorGenerator) reachableBefore(maze *domain.Maze, entry domain.Point, color int) []domain.Point {
+	visited := map[domain.Point]bool{entry: true}
+	queue := []domain.Point{entry}
+
+	for i := 0; i < len(queue); i++ {
+		for _, n := range passageNeighbors(maze, queue[i]) {
+			item := maze.ItemAt(n)
+			if visited[n] || (item.Kind == domain.DoorItem && item.Color >= color) {
+				continue
+			}
+
+			visited[n] = true
+			queue = append(queue, n)
+		}
+	}
+
+	return queue
+}
+
+func (g *KeyDoorGenerator) perm(n int) []int {
+	if g.Rand != nil {
+		return g.Rand.Perm(n)
+	}
+
+	return rand.Perm(n)
+}
+
+func (g *KeyDoorGenerator) intn(n int) int {
+	if g.Rand != nil {
+		return g.Rand.Intn(n)
+	}
+
+	return rand.Intn(n)
+}
@@ -0,0 +1,74 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// KeyDoorSolver finds the shortest path in a maze with colored keys and doors. It runs BFS over
+// (position, keys held) states, so the path may walk back and forth to collect the keys
+// in a valid order before passing the doors.
+type KeyDoorSolver struct{}
+
+// keyDoorState is a position together with the set of collected key colors.
+type keyDoorState struct {
+	point domain.Point
+	keys  uint32
+}
+
+func (s *KeyDoorSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	if err := maze.ValidateItems(); err != nil {
+		return nil, err
+	}
+
+	start := keyDoorState{point: entry, keys: s.pickUp(maze, entry, 0)}
+	queue := []keyDoorState{start}
+	visited := map[keyDoorState]bool{start: true}
+	parent := make(map[keyDoorState]keyDoorState)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		// If the exit point is reached, reconstruct the path
+		if current.point == exit {
+			var path []domain.Point
+			for state := current; state != start; state = parent[state] {
+				path = append([]domain.Point{state.point}, path...)
+			}
+
+			return append([]domain.Point{entry}, path...), nil
+		}
+
+		for _, neighbor := range passageNeighbors(maze, current.point) {
+			item := maze.ItemAt(neighbor)
+
+			// A door is passable only with the key of its color
+			if item.Kind == domain.DoorItem && current.keys&(1<<item.Color) == 0 {
+				continue
+			}
+
+			next := keyDoorState{point: neighbor, keys: s.pickUp(maze, neighbor, current.keys)}
+			if visited[next] {
+				continue
+			}
+
+			visited[next] = true
+			parent[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	// Path not found
+	return nil, domain.ErrUnreachableExit
+}
+
+// pickUp adds the key lying in the cell to the set of collected keys.
+func (s *KeyDoorSolver) pickUp(maze *domain.Maze, p domain.Point, keys uint32) uint32 {
+	if item := maze.ItemAt(p); item.Kind == domain.KeyItem {
+		keys |= 1 << item.Color
+	}
+
+	return keys
+}
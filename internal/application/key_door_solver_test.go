@@ -0,0 +1,166 @@
+package application_test
+
+import (
+	"errors"
+	"maps"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// newKeyDoorMaze builds a corridor blocked by a red door whose key lies in a side branch.
+func newKeyDoorMaze() (maze *domain.Maze, entry, exit domain.Point) {
+	maze = newMazeFromRows(
+		"#.#####",
+		"#.....#",
+		"#.#####",
+		"#.#####",
+		"#.#####",
+	)
+
+	maze.SetItem(domain.Point{X: 5, Y: 1}, domain.Item{Kind: domain.KeyItem})
+	maze.SetItem(domain.Point{X: 1, Y: 3}, domain.Item{Kind: domain.DoorItem})
+
+	return maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 4}
+}
+
+// assertKeyDoorPath checks that the path is walkable and never passes a door without its key.
+func assertKeyDoorPath(t *testing.T, maze *domain.Maze, path []domain.Point, entry, exit domain.Point) {
+	t.Helper()
+
+	if len(path) == 0 || path[0] != entry || path[len(path)-1] != exit {
+		t.Fatalf("Expected path from %v to %v, got %v", entry, exit, path)
+	}
+
+	keys := make(map[int]bool)
+
+	for i, p := range path {
+		if i > 0 {
+			prev := path[i-1]
+			if dx, dy := p.X-prev.X, p.Y-prev.Y; dx*dx+dy*dy != 1 {
+				t.Fatalf("Expected adjacent steps, got %v -> %v", prev, p)
+			}
+		}
+
+		if maze.Grid[p.Y][p.X].Wall {
+			t.Fatalf("Expected path to avoid walls, got %v", p)
+		}
+
+		switch item := maze.ItemAt(p); item.Kind {
+		case domain.KeyItem:
+			keys[item.Color] = true
+		case domain.DoorItem:
+			if !keys[item.Color] {
+				t.Fatalf("Expected key %d before the door at %v", item.Color, p)
+			}
+		}
+	}
+}
+
+func TestKeyDoorSolver_FindPath_CollectsKeyFirst(t *testing.T) {
+	maze, entry, exit := newKeyDoorMaze()
+
+	path, err := (&application.KeyDoorSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertKeyDoorPath(t, maze, path, entry, exit)
+
+	// Down to the branch, to the key and back, then through the door
+	if len(path) != 13 {
+		t.Errorf("Expected path of 13 cells, got %d", len(path))
+	}
+}
+
+func TestKeyDoorSolver_FindPath_MissingKey(t *testing.T) {
+	maze, entry, exit := newKeyDoorMaze()
+	maze.SetItem(domain.Point{X: 5, Y: 1}, domain.Item{})
+
+	_, err := (&application.KeyDoorSolver{}).FindPath(maze, entry, exit)
+	if !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+}
+
+func TestKeyDoorSolver_FindPath_InvalidColor(t *testing.T) {
+	for _, color := range []int{-1, domain.MaxItemColors} {
+		maze, entry, exit := newKeyDoorMaze()
+		maze.SetItem(domain.Point{X: 1, Y: 3}, domain.Item{Kind: domain.DoorItem, Color: color})
+
+		_, err := (&application.KeyDoorSolver{}).FindPath(maze, entry, exit)
+		if !errors.Is(err, domain.ErrInvalidItemColor) {
+			t.Errorf("Color %d: expected ErrInvalidItemColor, got %v", color, err)
+		}
+	}
+}
+
+func TestKeyDoorGenerator_Generate_Solvable(t *testing.T) {
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 19, Y: 20}
+
+	for i := 0; i < 20; i++ {
+		maze := domain.NewMaze(21, 21)
+
+		generator := application.NewKeyDoorGenerator(&application.KruskalGenerator{}, 3)
+		if err := generator.Generate(maze, entry, exit); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		counts := make(map[domain.Item]int)
+
+		for _, item := range maze.Items {
+			counts[item]++
+		}
+
+		for color := 0; color < 3; color++ {
+			for _, kind := range []domain.ItemKind{domain.KeyItem, domain.DoorItem} {
+				if counts[domain.Item{Kind: kind, Color: color}] != 1 {
+					t.Fatalf("Expected one item of kind %d and color %d, got %d", kind, color, counts[domain.Item{Kind: kind, Color: color}])
+				}
+			}
+		}
+
+		path, err := (&application.KeyDoorSolver{}).FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertKeyDoorPath(t, maze, path, entry, exit)
+	}
+}
+
+func TestKeyDoorGenerator_Generate_Reproducible(t *testing.T) {
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 19, Y: 20}
+
+	generate := func() *domain.Maze {
+		maze := domain.NewMaze(21, 21)
+
+		generator := application.NewKeyDoorGenerator(&application.KruskalGenerator{Rand: rand.New(rand.NewSource(3))}, 4)
+		generator.Rand = rand.New(rand.NewSource(5))
+
+		if err := generator.Generate(maze, entry, exit); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		return maze
+	}
+
+	if first, second := generate(), generate(); !maps.Equal(first.Items, second.Items) {
+		t.Errorf("Expected the same doors and keys for the same seeds, got %v and %v", first.Items, second.Items)
+	}
+}
+
+func TestKeyDoorGenerator_Generate_TooManyDoors(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+
+	generator := application.NewKeyDoorGenerator(&application.KruskalGenerator{}, domain.MaxItemColors+1)
+
+	err := generator.Generate(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 4})
+	if !errors.Is(err, application.ErrTooManyDoors) {
+		t.Errorf("Expected ErrTooManyDoors, got %v", err)
+	}
+}
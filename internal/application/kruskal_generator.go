@@ -1,12 +1,23 @@
 package application
 
 import (
+	"context"
 	"math/rand"
 
 	"github.com/abakunov/mazes/internal/domain"
 )
 
+// kruskalMaxAttempts limits how many times the maze is regenerated hoping to connect the exit.
+const kruskalMaxAttempts = 1000
+
+// kruskalValidator checks the invariants a new attempt can fix. The borders and alignment are kept
+// by construction for odd sizes, while even sizes carve into the last column or row by design.
+var kruskalValidator = domain.NewMazeValidator(domain.CheckReachability | domain.CheckLoops)
+
 type KruskalGenerator struct {
+	// Rand is the source of randomness; the global source is used if nil.
+	Rand *rand.Rand
+
 	parent map[int]int
 	rank   map[int]int
 }
@@ -53,12 +64,38 @@ func (g *KruskalGenerator) union(x, y int) {
 }
 
 // Generate creates a maze using Kruskal's algorithm with connectivity checking.
-func (g *KruskalGenerator) Generate(maze *domain.Maze, entry, exit domain.Point) {
-	for {
-		// Initialize all cells as walls
+func (g *KruskalGenerator) Generate(maze *domain.Maze, entry, exit domain.Point) error {
+	return g.GenerateContext(context.Background(), maze, entry, exit)
+}
+
+// GenerateContext creates a maze using Kruskal's algorithm, retrying until the exit is reachable,
+// the attempts run out or the context is done.
+func (g *KruskalGenerator) GenerateContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) error {
+	if err := maze.ValidateGenerationPoints(entry, exit); err != nil {
+		return err
+	}
+
+	// Openings no attempt can connect are reported right away instead of after every retry
+	for _, opening := range []struct {
+		name  string
+		point domain.Point
+	}{{"entry", entry}, {"exit", exit}} {
+		if !g.canConnect(maze, opening.point) {
+			return &domain.PointError{Name: opening.name, Point: opening.point, Err: domain.ErrUnreachableExit}
+		}
+	}
+
+	checker := newContextChecker(ctx)
+
+	for attempt := 0; attempt < kruskalMaxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Initialize everything but the cells as walls
 		for y := 0; y < maze.Height; y++ {
 			for x := 0; x < maze.Width; x++ {
-				maze.Grid[y][x].Wall = true
+				maze.Grid[y][x].Wall = !g.isCell(maze, domain.Point{X: x, Y: y})
 			}
 		}
 
@@ -83,10 +120,14 @@ func (g *KruskalGenerator) Generate(maze *domain.Maze, entry, exit domain.Point)
 		}
 
 		// Shuffle the walls
-		rand.Shuffle(len(walls), func(i, j int) { walls[i], walls[j] = walls[j], walls[i] })
+		g.shuffle(len(walls), func(i, j int) { walls[i], walls[j] = walls[j], walls[i] })
 
 		// Main Kruskal's algorithm
 		for _, wall := range walls {
+			if err := checker.check(); err != nil {
+				return err
+			}
+
 			// Find indices for Union-Find
 			cell1 := wall.y1*maze.Width + wall.x1
 			cell2 := wall.y2*maze.Width + wall.x2
@@ -105,17 +146,57 @@ func (g *KruskalGenerator) Generate(maze *domain.Maze, entry, exit domain.Point)
 		maze.Grid[entry.Y][entry.X].Wall = false
 		maze.Grid[exit.Y][exit.X].Wall = false
 
-		// Check if there is a path from entry to exit
-		if g.isPathAvailable(maze, entry, exit) {
-			break // Exit the loop if a path is found
+		// Check that the exit is connected and the maze stayed perfect
+		if kruskalValidator.Validate(maze, entry, exit).Valid() {
+			return nil
 		}
 	}
+
+	return &domain.PointError{Name: "exit", Point: exit, Err: domain.ErrUnreachableExit}
 }
 
-// isPathAvailable checks if there is a path from the entry point to the exit point.
-func (g *KruskalGenerator) isPathAvailable(maze *domain.Maze, entry, exit domain.Point) bool {
-	solver := &BFSSolver{}
-	path := solver.FindPath(maze, entry, exit)
+// canConnect checks whether some attempt can join the opening to the maze without a loop. Every
+// open point next to the opening is connected to the rest of the maze, so the opening needs
+// exactly one of them: it must touch at most one cell, and a cell or a wall that may be removed.
+func (g *KruskalGenerator) canConnect(maze *domain.Maze, opening domain.Point) bool {
+	cells, walls := 0, 0
+
+	for _, dir := range clockwiseDirections {
+		n := domain.Point{X: opening.X + dir.X, Y: opening.Y + dir.Y}
+
+		switch {
+		case g.isCell(maze, n):
+			cells++
+		case g.isRemovableWall(maze, n):
+			walls++
+		}
+	}
+
+	return cells <= 1 && cells+walls >= 1
+}
+
+// isCell checks whether the point is a cell joined by the algorithm: both coordinates odd, the
+// last column and row of even sizes included, except for their common corner.
+func (g *KruskalGenerator) isCell(maze *domain.Maze, p domain.Point) bool {
+	return maze.Contains(p) && p.X%2 == 1 && p.Y%2 == 1 && (p.X < maze.Width-1 || p.Y < maze.Height-1)
+}
+
+// isRemovableWall checks whether the point is one of the walls between two cells the algorithm considers.
+func (g *KruskalGenerator) isRemovableWall(maze *domain.Maze, p domain.Point) bool {
+	horizontal := p.X%2 == 0 && p.Y < maze.Height-1 &&
+		g.isCell(maze, domain.Point{X: p.X - 1, Y: p.Y}) && g.isCell(maze, domain.Point{X: p.X + 1, Y: p.Y})
+	vertical := p.Y%2 == 0 && p.X < maze.Width-1 &&
+		g.isCell(maze, domain.Point{X: p.X, Y: p.Y - 1}) && g.isCell(maze, domain.Point{X: p.X, Y: p.Y + 1})
+
+	return horizontal || vertical
+}
+
+// shuffle shuffles n elements using the generator's source of randomness.
+func (g *KruskalGenerator) shuffle(n int, swap func(i, j int)) {
+	if g.Rand != nil {
+		g.Rand.Shuffle(n, swap)
+		return
+	}
 
-	return path != nil
+	rand.Shuffle(n, swap)
 }
@@ -0,0 +1,92 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// diagonalDirections lists the diagonal movement directions clockwise starting from up-right.
+var diagonalDirections = []domain.Point{
+	{X: 1, Y: -1},  // Up-right
+	{X: 1, Y: 1},   // Down-right
+	{X: -1, Y: 1},  // Down-left
+	{X: -1, Y: -1}, // Up-left
+}
+
+// gridNeighbors returns the adjacent passage cells, including diagonal ones if enabled.
+// A diagonal step is only allowed when both orthogonal cells it passes are passages,
+// so moves never cut corners.
+func gridNeighbors(maze *domain.Maze, p domain.Point, diagonal bool) []domain.Point {
+	neighbors := passageNeighbors(maze, p)
+	if !diagonal {
+		return neighbors
+	}
+
+	for _, dir := range diagonalDirections {
+		if isPassage(maze, domain.Point{X: p.X + dir.X, Y: p.Y}) && isPassage(maze, domain.Point{X: p.X, Y: p.Y + dir.Y}) &&
+			isPassage(maze, domain.Point{X: p.X + dir.X, Y: p.Y + dir.Y}) {
+			neighbors = append(neighbors, domain.Point{X: p.X + dir.X, Y: p.Y + dir.Y})
+		}
+	}
+
+	return neighbors
+}
+
+// nextMoves returns the cells reachable from the point in a single move: the neighboring passages
+// that one-way cells let through, and the paired cell if the point is a teleporter.
+func nextMoves(maze *domain.Maze, p domain.Point, diagonal bool) []domain.Point {
+	var moves []domain.Point
+
+	for _, n := range gridNeighbors(maze, p, diagonal) {
+		if maze.CanStep(p, n) {
+			moves = append(moves, n)
+		}
+	}
+
+	if target, ok := maze.TeleportTarget(p); ok {
+		moves = append(moves, target)
+	}
+
+	return moves
+}
+
+// previousMoves returns the cells the point can be reached from in a single move, the reverse of
+// nextMoves: one-way cells are followed against their arrows, teleporters work both ways.
+func previousMoves(maze *domain.Maze, p domain.Point, diagonal bool) []domain.Point {
+	var moves []domain.Point
+
+	for _, n := range gridNeighbors(maze, p, diagonal) {
+		if maze.CanStep(n, p) {
+			moves = append(moves, n)
+		}
+	}
+
+	if target, ok := maze.TeleportTarget(p); ok {
+		moves = append(moves, target)
+	}
+
+	return moves
+}
+
+// moveCost returns the cost of a move scaled like JPS costs: diagonal steps cost more than
+// straight ones, and jumping through a teleporter costs as much as a straight step.
+func moveCost(from, to domain.Point) int {
+	if abs(to.X-from.X) == 1 && abs(to.Y-from.Y) == 1 {
+		return diagonalCost
+	}
+
+	return straightCost
+}
+
+// inward returns the cell next to a border cell on the inner side, or the cell itself inside the maze.
+func inward(maze *domain.Maze, p domain.Point) domain.Point {
+	switch {
+	case p.Y == 0:
+		return domain.Point{X: p.X, Y: 1}
+	case p.Y == maze.Height-1:
+		return domain.Point{X: p.X, Y: p.Y - 1}
+	case p.X == 0:
+		return domain.Point{X: 1, Y: p.Y}
+	case p.X == maze.Width-1:
+		return domain.Point{X: p.X - 1, Y: p.Y}
+	default:
+		return p
+	}
+}
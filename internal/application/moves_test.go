@@ -0,0 +1,147 @@
+package application_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// assertValidMoves checks that the path only makes moves allowed by one-way passages and teleporters.
+func assertValidMoves(t *testing.T, maze *domain.Maze, path []domain.Point, entry, exit domain.Point) {
+	t.Helper()
+
+	if len(path) == 0 || path[0] != entry || path[len(path)-1] != exit {
+		t.Fatalf("Expected path from %v to %v, got %v", entry, exit, path)
+	}
+
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+
+		if target, ok := maze.TeleportTarget(from); ok && target == to {
+			continue
+		}
+
+		if dx, dy := to.X-from.X, to.Y-from.Y; dx*dx+dy*dy != 1 || maze.Grid[to.Y][to.X].Wall || !maze.CanStep(from, to) {
+			t.Fatalf("Expected a valid move, got %v -> %v", from, to)
+		}
+	}
+}
+
+func TestSolvers_FindPath_Teleporter(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.#####",
+		"#.....#",
+		"#####.#",
+		"#.....#",
+		"#.#####",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 4}
+
+	if err := maze.AddTeleporter(domain.Point{X: 1, Y: 1}, domain.Point{X: 1, Y: 3}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, solver := range map[string]domain.Solver{"BFS": &application.BFSSolver{}, "A*": &application.AStarSolver{}} {
+		path, err := solver.FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		assertValidMoves(t, maze, path, entry, exit)
+
+		if len(path) != 4 {
+			t.Errorf("%s: expected path of 4 cells through the teleporter, got %v", name, path)
+		}
+	}
+}
+
+func TestSolvers_FindPath_OneWayPassage(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#...#",
+		"#.#.#",
+		"#...#",
+		"#.###",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 4}
+
+	if err := maze.SetOneWay(domain.Point{X: 1, Y: 2}, domain.Point{Y: -1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, solver := range map[string]domain.Solver{"BFS": &application.BFSSolver{}, "A*": &application.AStarSolver{}} {
+		// Going down the one-way passage is forbidden, so the path goes around
+		path, err := solver.FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		assertValidMoves(t, maze, path, entry, exit)
+
+		if len(path) != 9 {
+			t.Errorf("%s: expected detour of 9 cells, got %v", name, path)
+		}
+
+		// Going up takes the shortcut
+		path, err = solver.FindPath(maze, exit, entry)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(path) != 5 {
+			t.Errorf("%s: expected path of 5 cells along the one-way passage, got %v", name, path)
+		}
+	}
+}
+
+func TestAStarSolver_FindPath_OptimalWithTeleporters(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+
+	for i := 0; i < 50; i++ {
+		maze, entry, exit := newBraidedMaze(t, 31, 100, rng)
+
+		var passages []domain.Point
+
+		for y := 0; y < maze.Height; y++ {
+			for x := 0; x < maze.Width; x++ {
+				if !maze.Grid[y][x].Wall {
+					passages = append(passages, domain.Point{X: x, Y: y})
+				}
+			}
+		}
+
+		for j := 0; j < 3; j++ {
+			a, b := passages[rng.Intn(len(passages))], passages[rng.Intn(len(passages))]
+			_, pairedA := maze.TeleportTarget(a)
+			_, pairedB := maze.TeleportTarget(b)
+
+			if a != b && !pairedA && !pairedB {
+				_ = maze.AddTeleporter(a, b)
+			}
+		}
+
+		for j := 0; j < 20; j++ {
+			p := passages[rng.Intn(len(passages))]
+			_ = maze.SetOneWay(p, []domain.Point{{Y: -1}, {X: 1}, {Y: 1}, {X: -1}}[rng.Intn(4)])
+		}
+
+		expected, expectedErr := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+		path, err := (&application.AStarSolver{}).FindPath(maze, entry, exit)
+
+		if (err == nil) != (expectedErr == nil) {
+			t.Fatalf("Expected error %v, got %v", expectedErr, err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assertValidMoves(t, maze, path, entry, exit)
+
+		if len(path) != len(expected) {
+			t.Fatalf("Expected path of length %d, got %d", len(expected), len(path))
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package application
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// defaultPlacementSamples is the number of random pairs of openings rated when no number is set.
+const defaultPlacementSamples = 64
+
+// ErrNoOpenings is returned when fewer than two border cells lead into the same part of the maze.
+var ErrNoOpenings = errors.New("no pair of border cells leads into the maze")
+
+// OpeningScore rates a pair of openings by the maze and the solution between them.
+type OpeningScore func(maze *domain.Maze, entry, exit domain.Point, path []domain.Point) float64
+
+// OpeningPlacer moves the entry and exit of a generated maze to other border cells. Every
+// border cell next to an open cell inside the maze can become an opening.
+type OpeningPlacer struct {
+	// Samples is the number of random pairs rated by PlaceForScore; 64 is used if zero.
+	Samples int
+	// Rand picks the sampled pairs; the global source is used if nil.
+	Rand *rand.Rand
+}
+
+// NewOpeningPlacer initializes the OpeningPlacer.
+func NewOpeningPlacer() *OpeningPlacer {
+	return &OpeningPlacer{}
+}
+
+// PlaceLongest closes the current entry and exit and opens the pair of border cells with the
+// longest solution between them. The pair is found with two BFS runs: the opening farthest from
+// an arbitrary one is an end of the longest solution, and the opening farthest from that end is
+// the other. In a perfect maze this is exact, with loops it is a good approximation.
+func (p *OpeningPlacer) PlaceLongest(maze *domain.Maze, entry, exit domain.Point) (newEntry, newExit domain.Point, err error) {
+	candidates, err := p.closeOpenings(maze, entry, exit)
+	if err != nil {
+		return entry, exit, err
+	}
+
+	newEntry, newExit, err = p.diameter(maze, candidates)
+	if err != nil {
+		return entry, exit, p.reopen(maze, entry, exit, err)
+	}
+
+	p.open(maze, newEntry, newExit)
+
+	return newEntry, newExit, nil
+}
+
+// PlaceForScore closes the current entry and exit and opens the pair of border cells whose score
+// is the closest to the target. The longest pair and a number of random pairs are rated.
+func (p *OpeningPlacer) PlaceForScore(maze *domain.Maze, entry, exit domain.Point, target float64,
+	score OpeningScore) (newEntry, newExit domain.Point, err error) {
+	candidates, err := p.closeOpenings(maze, entry, exit)
+	if err != nil {
+		return entry, exit, err
+	}
+
+	longestEntry, longestExit, err := p.diameter(maze, candidates)
+	if err != nil {
+		return entry, exit, p.reopen(maze, entry, exit, err)
+	}
+
+	// Only openings into the same part of the maze as the longest pair can be connected
+	field, err := NewFlowFieldBuilder().Build(maze, inward(maze, longestEntry))
+	if err != nil {
+		return entry, exit, p.reopen(maze, entry, exit, err)
+	}
+
+	var connected []domain.Point
+
+	for _, c := range candidates {
+		if field.Distance(inward(maze, c)) != Unreachable {
+			connected = append(connected, c)
+		}
+	}
+
+	pairs := [][2]domain.Point{{longestEntry, longestExit}}
+
+	for i := 0; i < p.samples(); i++ {
+		a, b := connected[p.intn(len(connected))], connected[p.intn(len(connected))]
+		if a != b {
+			pairs = append(pairs, [2]domain.Point{a, b})
+		}
+	}
+
+	bestDiff := math.Inf(1)
+
+	for _, pair := range pairs {
+		p.open(maze, pair[0], pair[1])
+
+		exitField, err := NewFlowFieldBuilder().Build(maze, pair[1])
+		if err != nil {
+			p.closeCells(maze, pair[0], pair[1])
+			return entry, exit, p.reopen(maze, entry, exit, err)
+		}
+
+		path, err := exitField.PathFrom(pair[0])
+		if err != nil {
+			p.closeCells(maze, pair[0], pair[1])
+			return entry, exit, p.reopen(maze, entry, exit, err)
+		}
+
+		if diff := math.Abs(score(maze, pair[0], pair[1], path) - target); diff < bestDiff {
+			bestDiff, newEntry, newExit = diff, pair[0], pair[1]
+		}
+
+		p.closeCells(maze, pair[0], pair[1])
+	}
+
+	p.open(maze, newEntry, newExit)
+
+	return newEntry, newExit, nil
+}
+
+// closeOpenings turns the current openings into walls and returns the border cells that lead into the maze.
+func (p *OpeningPlacer) closeOpenings(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	if err := maze.ValidateGenerationPoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	p.closeCells(maze, entry, exit)
+
+	var candidates []domain.Point
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			b := domain.Point{X: x, Y: y}
+			if !p.isBorder(maze, b) || !maze.Grid[y][x].Wall {
+				continue
+			}
+
+			if inner := inward(maze, b); inner != b && !maze.Grid[inner.Y][inner.X].Wall {
+				candidates = append(candidates, b)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+// diameter returns the two candidates farthest apart, measured inside the maze.
+func (p *OpeningPlacer) diameter(maze *domain.Maze, candidates []domain.Point) (a, b domain.Point, err error) {
+	if len(candidates) < 2 {
+		return a, b, ErrNoOpenings
+	}
+
+	a, _, err = p.farthest(maze, candidates[0], candidates)
+	if err != nil {
+		return a, b, err
+	}
+
+	b, distance, err := p.farthest(maze, a, candidates)
+	if err != nil {
+		return a, b, err
+	}
+
+	if b == a || distance == Unreachable {
+		return a, b, ErrNoOpenings
+	}
+
+	return a, b, nil
+}
+
+// farthest returns the candidate other than from that is the farthest from it.
+func (p *OpeningPlacer) farthest(maze *domain.Maze, from domain.Point, candidates []domain.Point) (domain.Point, int, error) {
+	field, err := NewFlowFieldBuilder().Build(maze, inward(maze, from))
+	if err != nil {
+		return from, Unreachable, err
+	}
+
+	best, bestDistance := from, Unreachable
+
+	for _, c := range candidates {
+		if d := field.Distance(inward(maze, c)); c != from && d > bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	return best, bestDistance, nil
+}
+
+// reopen restores the original openings after a failed placement.
+func (p *OpeningPlacer) reopen(maze *domain.Maze, entry, exit domain.Point, err error) error {
+	p.open(maze, entry, exit)
+	return err
+}
+
+func (p *OpeningPlacer) open(maze *domain.Maze, cells ...domain.Point) {
+	for _, c := range cells {
+		maze.Grid[c.Y][c.X].Wall = false
+	}
+}
+
+func (p *OpeningPlacer) closeCells(maze *domain.Maze, cells ...domain.Point) {
+	for _, c := range cells {
+		maze.Grid[c.Y][c.X].Wall = true
+	}
+}
+
+// isBorder checks whether the point lies on the border of the maze, corners excluded.
+func (p *OpeningPlacer) isBorder(maze *domain.Maze, b domain.Point) bool {
+	onX := b.X == 0 || b.X == maze.Width-1
+	onY := b.Y == 0 || b.Y == maze.Height-1
+
+	return onX != onY
+}
+
+func (p *OpeningPlacer) samples() int {
+	if p.Samples > 0 {
+		return p.Samples
+	}
+
+	return defaultPlacementSamples
+}
+
+func (p *OpeningPlacer) intn(n int) int {
+	if p.Rand != nil {
+		return p.Rand.Intn(n)
+	}
+
+	return rand.Intn(n)
+}
@@ -0,0 +1,161 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// borderOpenings returns the border cells, corners excluded, next to an open cell inside the maze.
+func borderOpenings(maze *domain.Maze) []domain.Point {
+	var openings []domain.Point
+
+	for x := 1; x < maze.Width-1; x++ {
+		if !maze.Grid[1][x].Wall {
+			openings = append(openings, domain.Point{X: x, Y: 0})
+		}
+
+		if !maze.Grid[maze.Height-2][x].Wall {
+			openings = append(openings, domain.Point{X: x, Y: maze.Height - 1})
+		}
+	}
+
+	for y := 1; y < maze.Height-1; y++ {
+		if !maze.Grid[y][1].Wall {
+			openings = append(openings, domain.Point{X: 0, Y: y})
+		}
+
+		if !maze.Grid[y][maze.Width-2].Wall {
+			openings = append(openings, domain.Point{X: maze.Width - 1, Y: y})
+		}
+	}
+
+	return openings
+}
+
+// newPerfectMaze generates a Kruskal maze and returns it with its openings.
+func newPerfectMaze(t *testing.T, width, height int) (*domain.Maze, domain.Point, domain.Point) {
+	t.Helper()
+
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: width - 2, Y: height - 1}
+
+	maze := domain.NewMaze(width, height)
+	if err := (&application.KruskalGenerator{Rand: rand.New(rand.NewSource(int64(width * height)))}).Generate(maze, entry, exit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return maze, entry, exit
+}
+
+func TestOpeningPlacer_PlaceLongest(t *testing.T) {
+	for _, size := range []domain.Point{{X: 11, Y: 11}, {X: 21, Y: 15}, {X: 31, Y: 9}} {
+		maze, entry, exit := newPerfectMaze(t, size.X, size.Y)
+
+		entry, exit, err := application.NewOpeningPlacer().PlaceLongest(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := domain.NewMazeValidator(domain.AllChecks).Validate(maze, entry, exit).Err(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		path, err := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// Brute force over every pair of possible openings, measured between the cells inside
+		maze.Grid[entry.Y][entry.X].Wall = true
+		maze.Grid[exit.Y][exit.X].Wall = true
+
+		openings := borderOpenings(maze)
+		longest := 0
+
+		for _, a := range openings {
+			maze.Grid[a.Y][a.X].Wall = false
+
+			field, err := application.NewFlowFieldBuilder().Build(maze, a)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for _, b := range openings {
+				if b == a {
+					continue
+				}
+
+				// The closed opening is one step past the cell inside, and the path counts both ends
+				inner := domain.Point{X: min(max(b.X, 1), maze.Width-2), Y: min(max(b.Y, 1), maze.Height-2)}
+				if d := field.Distance(inner); d != application.Unreachable {
+					longest = max(longest, d+2)
+				}
+			}
+
+			maze.Grid[a.Y][a.X].Wall = true
+		}
+
+		if len(path) != longest {
+			t.Errorf("%dx%d: expected the longest solution of %d cells, got %d", size.X, size.Y, longest, len(path))
+		}
+	}
+}
+
+func TestOpeningPlacer_PlaceForScore(t *testing.T) {
+	maze, entry, exit := newPerfectMaze(t, 21, 21)
+
+	length := func(_ *domain.Maze, _, _ domain.Point, path []domain.Point) float64 {
+		return float64(len(path))
+	}
+
+	placer := &application.OpeningPlacer{Samples: 200, Rand: rand.New(rand.NewSource(3))}
+
+	const target = 40
+
+	entry, exit, err := placer.PlaceForScore(maze, entry, exit, target, length)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := domain.NewMazeValidator(domain.AllChecks).Validate(maze, entry, exit).Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	path, err := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Many pairs are rated, so a solution close to the target is found
+	if len(path) < target-5 || len(path) > target+5 {
+		t.Errorf("Expected a solution of about %d cells, got %d", target, len(path))
+	}
+}
+
+func TestOpeningPlacer_NoOpenings(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			maze.Grid[y][x].Wall = true
+		}
+	}
+
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 4}
+	maze.Grid[entry.Y][entry.X].Wall = false
+	maze.Grid[exit.Y][exit.X].Wall = false
+
+	_, _, err := application.NewOpeningPlacer().PlaceLongest(maze, entry, exit)
+	if !errors.Is(err, application.ErrNoOpenings) {
+		t.Errorf("Expected ErrNoOpenings, got %v", err)
+	}
+
+	// The original openings are kept
+	if maze.Grid[entry.Y][entry.X].Wall || maze.Grid[exit.Y][exit.X].Wall {
+		t.Error("Expected the original openings to be restored")
+	}
+}
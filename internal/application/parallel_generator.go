@@ -0,0 +1,393 @@
+package application
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// defaultTileSize is the side of a tile in maze cells when no size is set.
+const defaultTileSize = 64
+
+// ParallelGenerator generates very large perfect mazes using several goroutines. Maze cells
+// lie on odd coordinates with walls between them, as in KruskalGenerator. The cells are split
+// into square tiles, each tile is carved into a spanning tree concurrently with Kruskal's
+// algorithm, and the tiles are then stitched together along a random spanning tree of the
+// tile grid by opening a single wall between each pair of joined tiles. The union of spanning
+// trees joined by a tree of single passages is itself a spanning tree.
+type ParallelGenerator struct {
+	// Workers is the number of goroutines carving tiles; GOMAXPROCS is used if zero.
+	Workers int
+	// TileSize is the side of a tile in maze cells; 64 is used if zero.
+	TileSize int
+	// Rand seeds the tiles and picks the stitching walls; the global source is used if nil.
+	Rand *rand.Rand
+}
+
+// NewParallelGenerator initializes the ParallelGenerator with the number of workers.
+func NewParallelGenerator(workers int) *ParallelGenerator {
+	return &ParallelGenerator{Workers: workers}
+}
+
+// latticeEdge joins two neighboring maze cells given in cell coordinates.
+type latticeEdge struct {
+	a, b domain.Point
+}
+
+// tile is a rectangle of maze cells in cell coordinates, [x0, x1) × [y0, y1).
+type tile struct {
+	x0, y0, x1, y1 int
+}
+
+// Generate creates a maze carving tiles in parallel.
+func (g *ParallelGenerator) Generate(maze *domain.Maze, entry, exit domain.Point) error {
+	return g.GenerateContext(context.Background(), maze, entry, exit)
+}
+
+// GenerateContext creates a maze carving tiles in parallel, stopping early if the context is done.
+func (g *ParallelGenerator) GenerateContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) error {
+	if err := maze.ValidateGenerationPoints(entry, exit); err != nil {
+		return err
+	}
+
+	// Maze cells in cell coordinates: cell (cx, cy) is the grid point (2cx+1, 2cy+1)
+	cellsX, cellsY := (maze.Width-1)/2, (maze.Height-1)/2
+	size := g.tileSize()
+	tilesX, tilesY := (cellsX+size-1)/size, (cellsY+size-1)/size
+
+	// Openings off the cell lattice may need a specific wall removed to stay connected
+	var forced []latticeEdge
+
+	for _, p := range []domain.Point{entry, exit} {
+		edge, ok, err := g.openingEdge(maze, p, exit)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			forced = append(forced, edge)
+		}
+	}
+
+	tiles := make([]tile, 0, tilesX*tilesY)
+	seeds := make([]int64, 0, tilesX*tilesY)
+
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			tiles = append(tiles, tile{
+				x0: tx * size, y0: ty * size,
+				x1: min((tx+1)*size, cellsX), y1: min((ty+1)*size, cellsY),
+			})
+			seeds = append(seeds, g.int63())
+		}
+	}
+
+	tileOf := func(p domain.Point) int { return (p.Y/size)*tilesX + p.X/size }
+	joined := g.joinedCells(forced, tileOf)
+
+	if err := g.carveTiles(ctx, maze, tiles, seeds, forced, joined); err != nil {
+		return err
+	}
+
+	g.stitchTiles(maze, tiles, tilesX, tileOf, forced)
+
+	// Open the entry and the exit, along with the cell next to them if it lies off the lattice
+	for _, p := range []domain.Point{entry, exit} {
+		maze.Grid[p.Y][p.X].Wall = false
+
+		if inner := inward(maze, p); inner != p {
+			maze.Grid[inner.Y][inner.X].Wall = false
+		}
+	}
+
+	return nil
+}
+
+// carveTiles carves every tile into a spanning tree using a pool of goroutines. Each tile owns
+// the grid columns and rows from the wall before its first cell up to its last cell, the last
+// tiles also own the rest of the grid, so no two goroutines write the same cell.
+func (g *ParallelGenerator) carveTiles(ctx context.Context, maze *domain.Maze, tiles []tile, seeds []int64,
+	forced, joined []latticeEdge) error {
+	jobs := make(chan int)
+	errs := make(chan error, g.workers())
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < g.workers(); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				if err := g.carveTile(ctx, maze, tiles[i], rand.New(rand.NewSource(seeds[i])), forced, joined); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	var err error
+
+feed:
+	for i := range tiles {
+		select {
+		case jobs <- i:
+		case err = <-errs:
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err = <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+// carveTile fills the grid area owned by the tile with walls and carves its cells into a spanning
+// tree with Kruskal's algorithm, taking the forced walls inside the tile first. The joined cells
+// inside the tile are treated as already connected, leaving the tile split into two trees.
+func (g *ParallelGenerator) carveTile(ctx context.Context, maze *domain.Maze, t tile, rng *rand.Rand,
+	forced, joined []latticeEdge) error {
+	checker := newContextChecker(ctx)
+
+	// Grid area owned by the tile
+	gx0, gy0, gx1, gy1 := 2*t.x0, 2*t.y0, 2*t.x1, 2*t.y1
+	if 2*t.x1+1 >= maze.Width-1 {
+		gx1 = maze.Width
+	}
+
+	if 2*t.y1+1 >= maze.Height-1 {
+		gy1 = maze.Height
+	}
+
+	for y := gy0; y < gy1; y++ {
+		for x := gx0; x < gx1; x++ {
+			if err := checker.check(); err != nil {
+				return err
+			}
+
+			maze.Grid[y][x] = domain.Cell{Wall: true}
+		}
+	}
+
+	width := t.x1 - t.x0
+	parent := make([]int, width*(t.y1-t.y0))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	index := func(p domain.Point) int { return (p.Y-t.y0)*width + p.X - t.x0 }
+	inside := func(p domain.Point) bool { return p.X >= t.x0 && p.X < t.x1 && p.Y >= t.y0 && p.Y < t.y1 }
+
+	edges := g.openCells(maze, t)
+
+	rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+
+	for _, e := range joined {
+		if inside(e.a) && inside(e.b) {
+			parent[findRoot(parent, index(e.a))] = findRoot(parent, index(e.b))
+		}
+	}
+
+	for _, e := range forced {
+		if inside(e.a) && inside(e.b) {
+			edges = append([]latticeEdge{e}, edges...)
+		}
+	}
+
+	for _, e := range edges {
+		if err := checker.check(); err != nil {
+			return err
+		}
+
+		if a, b := findRoot(parent, index(e.a)), findRoot(parent, index(e.b)); a != b {
+			parent[a] = b
+			g.openEdge(maze, e)
+		}
+	}
+
+	return nil
+}
+
+// openCells opens the maze cells of the tile and returns the walls between them.
+func (g *ParallelGenerator) openCells(maze *domain.Maze, t tile) []latticeEdge {
+	var edges []latticeEdge
+
+	for cy := t.y0; cy < t.y1; cy++ {
+		for cx := t.x0; cx < t.x1; cx++ {
+			maze.Grid[2*cy+1][2*cx+1].Wall = false
+
+			if cx+1 < t.x1 {
+				edges = append(edges, latticeEdge{domain.Point{X: cx, Y: cy}, domain.Point{X: cx + 1, Y: cy}})
+			}
+
+			if cy+1 < t.y1 {
+				edges = append(edges, latticeEdge{domain.Point{X: cx, Y: cy}, domain.Point{X: cx, Y: cy + 1}})
+			}
+		}
+	}
+
+	return edges
+}
+
+// stitchTiles joins the tiles along a random spanning tree of the tile grid, opening one wall
+// between each pair of joined tiles. Forced walls crossing tile borders are always opened first,
+// a second one between the same pair of tiles is balanced by the cells joined in carveTile.
+func (g *ParallelGenerator) stitchTiles(maze *domain.Maze, tiles []tile, tilesX int, tileOf func(domain.Point) int,
+	forced []latticeEdge) {
+	var borders []latticeEdge
+
+	for i, t := range tiles {
+		// A random wall on the right and bottom borders of the tile
+		if (i+1)%tilesX != 0 {
+			cy := t.y0 + g.intn(t.y1-t.y0)
+			borders = append(borders, latticeEdge{domain.Point{X: t.x1 - 1, Y: cy}, domain.Point{X: t.x1, Y: cy}})
+		}
+
+		if i+tilesX < len(tiles) {
+			cx := t.x0 + g.intn(t.x1-t.x0)
+			borders = append(borders, latticeEdge{domain.Point{X: cx, Y: t.y1 - 1}, domain.Point{X: cx, Y: t.y1}})
+		}
+	}
+
+	for i := len(borders) - 1; i > 0; i-- {
+		j := g.intn(i + 1)
+		borders[i], borders[j] = borders[j], borders[i]
+	}
+
+	parent := make([]int, len(tiles))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	for _, e := range forced {
+		if a, b := tileOf(e.a), tileOf(e.b); a != b {
+			parent[findRoot(parent, a)] = findRoot(parent, b)
+			g.openEdge(maze, e)
+		}
+	}
+
+	for _, e := range borders {
+		if a, b := findRoot(parent, tileOf(e.a)), findRoot(parent, tileOf(e.b)); a != b {
+			parent[a] = b
+			g.openEdge(maze, e)
+		}
+	}
+}
+
+// openingEdge returns the wall between two maze cells that has to be open for the entry or exit
+// to join the maze without creating a loop. That is the case when the grid point next to the
+// opening is a wall between two cells; a point next to a single cell is simply opened.
+func (g *ParallelGenerator) openingEdge(maze *domain.Maze, p, exit domain.Point) (latticeEdge, bool, error) {
+	inner := inward(maze, p)
+
+	var cells []domain.Point
+
+	for _, dir := range append([]domain.Point{{}}, clockwiseDirections...) {
+		n := domain.Point{X: inner.X + dir.X, Y: inner.Y + dir.Y}
+		if n.X%2 == 1 && n.Y%2 == 1 && n.X < maze.Width-1 && n.Y < maze.Height-1 {
+			cells = append(cells, domain.Point{X: n.X / 2, Y: n.Y / 2})
+		}
+	}
+
+	switch len(cells) {
+	case 0:
+		name := "entry"
+		if p == exit {
+			name = "exit"
+		}
+
+		return latticeEdge{}, false, &domain.PointError{Name: name, Point: p, Err: domain.ErrUnreachableExit}
+	case 2:
+		return latticeEdge{cells[0], cells[1]}, true, nil
+	default:
+		return latticeEdge{}, false, nil
+	}
+}
+
+// joinedCells handles the entry and exit forcing walls across the border of the same two tiles.
+// Opening both would close a loop through the tiles, so the cells of the two walls on one side
+// of the border are returned to be carved into separate trees, each attached through its wall.
+func (g *ParallelGenerator) joinedCells(forced []latticeEdge, tileOf func(domain.Point) int) []latticeEdge {
+	if len(forced) != 2 {
+		return nil
+	}
+
+	first, second := forced[0], forced[1]
+	if tileOf(second.a) != tileOf(first.a) {
+		second.a, second.b = second.b, second.a
+	}
+
+	if tileOf(first.a) == tileOf(first.b) || tileOf(second.a) != tileOf(first.a) || tileOf(second.b) != tileOf(first.b) {
+		return nil
+	}
+
+	// The walls differ, so they differ in at least one of their cells
+	if first.a != second.a {
+		return []latticeEdge{{first.a, second.a}}
+	}
+
+	return []latticeEdge{{first.b, second.b}}
+}
+
+// openEdge removes the wall between two neighboring maze cells.
+func (g *ParallelGenerator) openEdge(maze *domain.Maze, e latticeEdge) {
+	maze.Grid[e.a.Y+e.b.Y+1][e.a.X+e.b.X+1].Wall = false
+}
+
+func (g *ParallelGenerator) int63() int64 {
+	if g.Rand != nil {
+		return g.Rand.Int63()
+	}
+
+	return rand.Int63()
+}
+
+func (g *ParallelGenerator) intn(n int) int {
+	if g.Rand != nil {
+		return g.Rand.Intn(n)
+	}
+
+	return rand.Intn(n)
+}
+
+func (g *ParallelGenerator) workers() int {
+	if g.Workers > 0 {
+		return g.Workers
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+func (g *ParallelGenerator) tileSize() int {
+	if g.TileSize > 0 {
+		return g.TileSize
+	}
+
+	return defaultTileSize
+}
+
+// findRoot returns the representative of the set containing x, halving the path on the way.
+func findRoot(parent []int, x int) int {
+	for parent[x] != x {
+		parent[x] = parent[parent[x]]
+		x = parent[x]
+	}
+
+	return x
+}
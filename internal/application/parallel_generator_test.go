@@ -0,0 +1,56 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestParallelGenerator_Generate_SpanningTree(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		entry, exit   domain.Point
+		workers, tile int
+	}{
+		{"single worker", 61, 41, domain.Point{X: 1, Y: 0}, domain.Point{X: 59, Y: 40}, 1, 4},
+		{"many workers", 101, 81, domain.Point{X: 1, Y: 0}, domain.Point{X: 99, Y: 80}, 8, 5},
+		{"single tile", 21, 21, domain.Point{X: 0, Y: 5}, domain.Point{X: 20, Y: 15}, 4, 0},
+		{"openings between cells", 41, 41, domain.Point{X: 8, Y: 0}, domain.Point{X: 40, Y: 16}, 4, 4},
+		{"even size", 40, 30, domain.Point{X: 2, Y: 0}, domain.Point{X: 37, Y: 29}, 3, 3},
+		{"openings across the same tile border", 257, 21, domain.Point{X: 128, Y: 0}, domain.Point{X: 128, Y: 20}, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				maze := domain.NewMaze(tt.width, tt.height)
+
+				generator := &application.ParallelGenerator{Workers: tt.workers, TileSize: tt.tile}
+				if err := generator.Generate(maze, tt.entry, tt.exit); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				// A perfect maze on the cell lattice is a spanning tree of its cells
+				if err := domain.NewMazeValidator(domain.AllChecks).Validate(maze, tt.entry, tt.exit).Err(); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestParallelGenerator_GenerateContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 199, Y: 200}
+
+	err := application.NewParallelGenerator(4).GenerateContext(ctx, domain.NewMaze(201, 201), entry, exit)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
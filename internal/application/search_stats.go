@@ -0,0 +1,62 @@
+package application
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// searchTrace accumulates the work done by a solver during a single search.
+type searchTrace struct {
+	expanded    int
+	maxFrontier int
+	visited     func() []domain.Point
+}
+
+// expand records the expansion of a node while the frontier holds the given number of nodes.
+func (t *searchTrace) expand(frontier int) {
+	t.expanded++
+	t.maxFrontier = max(t.maxFrontier, frontier)
+}
+
+// visitedFrom makes the trace report the keys of the map as the visited set.
+func visitedFrom[V any](visited map[domain.Point]V) func() []domain.Point {
+	return func() []domain.Point {
+		points := make([]domain.Point, 0, len(visited))
+		for p := range visited {
+			points = append(points, p)
+		}
+
+		return points
+	}
+}
+
+// collectStats runs the search and measures its duration and allocations.
+func collectStats(search func(trace *searchTrace) ([]domain.Point, error)) ([]domain.Point, domain.SearchStats, error) {
+	var before, after runtime.MemStats
+
+	trace := &searchTrace{}
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	path, err := search(trace)
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	stats := domain.SearchStats{
+		NodesExpanded:  trace.expanded,
+		MaxFrontier:    trace.maxFrontier,
+		Elapsed:        elapsed,
+		Allocations:    after.Mallocs - before.Mallocs,
+		AllocatedBytes: after.TotalAlloc - before.TotalAlloc,
+	}
+
+	if trace.visited != nil {
+		stats.Visited = trace.visited()
+	}
+
+	return path, stats, err
+}
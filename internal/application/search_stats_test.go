@@ -0,0 +1,50 @@
+package application_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestFindPathWithStats(t *testing.T) {
+	maze, entry, exit := newBraidedMaze(t, 41, 200, rand.New(rand.NewSource(7)))
+
+	solvers := map[string]domain.InstrumentedSolver{
+		"BFS":               &application.BFSSolver{},
+		"A*":                &application.AStarSolver{},
+		"Bidirectional BFS": &application.BidirectionalBFSSolver{},
+		"JPS":               &application.JPSSolver{},
+	}
+
+	expected, err := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, solver := range solvers {
+		path, stats, err := solver.FindPathWithStats(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+
+		if len(path) != len(expected) {
+			t.Errorf("%s: expected path of length %d, got %d", name, len(expected), len(path))
+		}
+
+		if stats.NodesExpanded == 0 || stats.MaxFrontier == 0 {
+			t.Errorf("%s: expected expanded nodes and frontier to be recorded, got %+v", name, stats)
+		}
+
+		if len(stats.Visited) < stats.NodesExpanded && name != "JPS" {
+			t.Errorf("%s: expected at least %d visited cells, got %d", name, stats.NodesExpanded, len(stats.Visited))
+		}
+
+		if stats.Elapsed <= 0 {
+			t.Errorf("%s: expected elapsed time to be measured", name)
+		}
+	}
+}
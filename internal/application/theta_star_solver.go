@@ -0,0 +1,196 @@
+package application
+
+import (
+	"container/heap"
+	"context"
+	"math"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// ThetaStarSolver finds any-angle paths on open grids such as cave maps. It searches like
+// 8-connected A*, but lets a cell inherit the parent of its predecessor whenever the two are
+// in line of sight, so the path is made of straight segments at arbitrary angles. The returned
+// path holds only the turning points; consecutive points are connected by a straight line that
+// touches no walls and squeezes between no diagonal corners. Teleporters and one-way passages
+// are ignored.
+type ThetaStarSolver struct{}
+
+// thetaNode is a cell reached by Theta* with its Euclidean cost from the entry.
+type thetaNode struct {
+	point    domain.Point
+	cost     float64
+	priority float64
+	index    int
+	parent   *thetaNode
+}
+
+// thetaQueue is a priority queue of Theta* nodes ordered by their floating-point priority.
+type thetaQueue []*thetaNode
+
+func (q thetaQueue) Len() int { return len(q) }
+
+func (q thetaQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+
+func (q thetaQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *thetaQueue) Push(x interface{}) {
+	n := x.(*thetaNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+
+func (q *thetaQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	node := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return node
+}
+
+func (s *ThetaStarSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(context.Background(), maze, entry, exit, &searchTrace{})
+}
+
+// FindPathContext finds the path, stopping early if the context is done.
+func (s *ThetaStarSolver) FindPathContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.search(ctx, maze, entry, exit, &searchTrace{})
+}
+
+// FindPathWithStats finds the path and reports the work done by the search.
+func (s *ThetaStarSolver) FindPathWithStats(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, domain.SearchStats, error) {
+	return collectStats(func(trace *searchTrace) ([]domain.Point, error) {
+		return s.search(context.Background(), maze, entry, exit, trace)
+	})
+}
+
+// search runs Theta* recording its work in the trace.
+func (s *ThetaStarSolver) search(ctx context.Context, maze *domain.Maze, entry, exit domain.Point,
+	trace *searchTrace) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	checker := newContextChecker(ctx)
+
+	queue := &thetaQueue{}
+	heap.Init(queue)
+	heap.Push(queue, &thetaNode{point: entry, priority: euclideanDistance(entry, exit)})
+
+	best := map[domain.Point]float64{entry: 0}
+	closed := make(map[domain.Point]bool)
+	trace.visited = visitedFrom(best)
+
+	for queue.Len() > 0 {
+		if err := checker.check(); err != nil {
+			return nil, err
+		}
+
+		frontier := queue.Len()
+
+		current := heap.Pop(queue).(*thetaNode)
+		if closed[current.point] {
+			continue // Outdated queue entry
+		}
+
+		trace.expand(frontier)
+
+		closed[current.point] = true
+
+		if current.point == exit {
+			var path []domain.Point
+			for n := current; n != nil; n = n.parent {
+				path = append([]domain.Point{n.point}, path...)
+			}
+
+			return path, nil
+		}
+
+		for _, neighbor := range gridNeighbors(maze, current.point, true) {
+			if closed[neighbor] {
+				continue
+			}
+
+			// Skip the current cell if its parent can see the neighbor directly
+			parent := current
+			if current.parent != nil && s.lineOfSight(maze, current.parent.point, neighbor) {
+				parent = current.parent
+			}
+
+			newCost := parent.cost + euclideanDistance(parent.point, neighbor)
+			if cost, seen := best[neighbor]; seen && cost <= newCost {
+				continue
+			}
+
+			best[neighbor] = newCost
+			heap.Push(queue, &thetaNode{
+				point:    neighbor,
+				cost:     newCost,
+				priority: newCost + euclideanDistance(neighbor, exit),
+				parent:   parent,
+			})
+		}
+	}
+
+	// Path not found
+	return nil, domain.ErrUnreachableExit
+}
+
+// lineOfSight walks every cell the segment between the centers of two cells passes through
+// and checks that none of them is a wall. Where the segment crosses a grid corner exactly,
+// both cells beside the corner must be passages.
+func (s *ThetaStarSolver) lineOfSight(maze *domain.Maze, from, to domain.Point) bool {
+	dx, dy := abs(to.X-from.X), abs(to.Y-from.Y)
+	stepX, stepY := sign(to.X-from.X), sign(to.Y-from.Y)
+	current := from
+
+	for ix, iy := 0, 0; ix < dx || iy < dy; {
+		// Compare where the segment crosses the next vertical and horizontal grid lines
+		decision := (1+2*ix)*dy - (1+2*iy)*dx
+
+		switch {
+		case decision == 0:
+			if !isPassage(maze, domain.Point{X: current.X + stepX, Y: current.Y}) ||
+				!isPassage(maze, domain.Point{X: current.X, Y: current.Y + stepY}) {
+				return false
+			}
+
+			current.X += stepX
+			current.Y += stepY
+			ix++
+			iy++
+		case decision < 0:
+			current.X += stepX
+			ix++
+		default:
+			current.Y += stepY
+			iy++
+		}
+
+		if !isPassage(maze, current) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EuclideanLength returns the length of a path whose consecutive points are joined by straight lines.
+func EuclideanLength(path []domain.Point) float64 {
+	length := 0.0
+	for i := 1; i < len(path); i++ {
+		length += euclideanDistance(path[i-1], path[i])
+	}
+
+	return length
+}
+
+func euclideanDistance(a, b domain.Point) float64 {
+	return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y))
+}
@@ -0,0 +1,112 @@
+package application_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// assertClearSegments samples every segment of an any-angle path and checks that it stays out of walls.
+func assertClearSegments(t *testing.T, maze *domain.Maze, path []domain.Point) {
+	t.Helper()
+
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		steps := 100 * (1 + int(math.Hypot(float64(to.X-from.X), float64(to.Y-from.Y))))
+
+		for j := 0; j <= steps; j++ {
+			f := float64(j) / float64(steps)
+			x := float64(from.X) + f*float64(to.X-from.X)
+			y := float64(from.Y) + f*float64(to.Y-from.Y)
+
+			// Skip samples exactly on a cell border, which belong to both cells
+			if math.Abs(x-math.Floor(x)-0.5) < 1e-9 || math.Abs(y-math.Floor(y)-0.5) < 1e-9 {
+				continue
+			}
+
+			if cell := maze.Grid[int(math.Round(y))][int(math.Round(x))]; cell.Wall {
+				t.Fatalf("Segment %v -> %v passes through a wall near (%.2f, %.2f)", from, to, x, y)
+			}
+		}
+	}
+}
+
+func TestThetaStarSolver_FindPath_OpenGrid(t *testing.T) {
+	maze := domain.NewMaze(10, 7)
+	entry := domain.Point{X: 0, Y: 0}
+	exit := domain.Point{X: 9, Y: 6}
+
+	path, err := (&application.ThetaStarSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Nothing in the way: a single straight segment
+	if len(path) != 2 || path[0] != entry || path[1] != exit {
+		t.Errorf("Expected a straight segment from %v to %v, got %v", entry, exit, path)
+	}
+}
+
+func TestThetaStarSolver_FindPath_AroundObstacle(t *testing.T) {
+	maze := newMazeFromRows(
+		"..........",
+		"..........",
+		"....###...",
+		"....###...",
+		"..........",
+	)
+	entry := domain.Point{X: 0, Y: 3}
+	exit := domain.Point{X: 9, Y: 3}
+
+	path, err := (&application.ThetaStarSolver{}).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertClearSegments(t, maze, path)
+
+	if len(path) < 3 {
+		t.Errorf("Expected the path to turn around the obstacle, got %v", path)
+	}
+}
+
+func TestThetaStarSolver_FindPath_ShorterThanOctile(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+
+	for i := 0; i < 200; i++ {
+		maze := newRandomGrid(4+rng.Intn(12), 4+rng.Intn(12), rng.Float64()*0.3, rng)
+		entry := domain.Point{X: 0, Y: 0}
+		exit := domain.Point{X: maze.Width - 1, Y: maze.Height - 1}
+
+		expected := dijkstraOctileCost(maze, entry, exit)
+		path, err := (&application.ThetaStarSolver{}).FindPath(maze, entry, exit)
+
+		if expected < 0 {
+			if !errors.Is(err, domain.ErrUnreachableExit) {
+				t.Fatalf("Expected ErrUnreachableExit, got %v", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if path[0] != entry || path[len(path)-1] != exit {
+			t.Fatalf("Expected path from %v to %v, got %v", entry, exit, path)
+		}
+
+		assertClearSegments(t, maze, path)
+
+		// Octile costs round √2 down to 1.4, so scale the bound back up
+		bound := float64(expected) / 10 * math.Sqrt2 / 1.4
+		if length := application.EuclideanLength(path); length > bound+1e-9 {
+			t.Fatalf("Expected any-angle path no longer than %.3f, got %.3f", bound, length)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// maxSchedulePeriod limits how long a schedule may take to repeat, as the search keeps a state
+// for every cell at every step of the period.
+const maxSchedulePeriod = 1 << 12
+
+// ErrScheduleTooLong is returned when the hazards of a schedule take too long to repeat together.
+var ErrScheduleTooLong = errors.New("schedule period is too long")
+
+// TimedSolver finds the earliest arrival at the exit in a maze with hazards that block cells on
+// a schedule. It runs BFS over (position, time) states where waiting in place is a move, and
+// since the schedule repeats, time is only tracked modulo its period. The returned path holds
+// the position at every time step, so waiting shows up as a repeated cell. Teleporters and
+// one-way passages are ignored.
+type TimedSolver struct {
+	Schedule domain.Schedule
+}
+
+// NewTimedSolver initializes the TimedSolver.
+func NewTimedSolver(schedule domain.Schedule) *TimedSolver {
+	return &TimedSolver{Schedule: schedule}
+}
+
+// timedState is a position at a step of the schedule period.
+type timedState struct {
+	point domain.Point
+	phase int
+}
+
+func (s *TimedSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	return s.FindPathContext(context.Background(), maze, entry, exit)
+}
+
+// FindPathContext finds the path, stopping early if the context is done.
+func (s *TimedSolver) FindPathContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	if err := s.Schedule.Validate(maze); err != nil {
+		return nil, err
+	}
+
+	period, ok := s.Schedule.PeriodWithin(maxSchedulePeriod)
+	if !ok {
+		return nil, fmt.Errorf("%w: more than %d steps", ErrScheduleTooLong, maxSchedulePeriod)
+	}
+
+	// Starting in a blocked cell is as hopeless as a walled-off exit
+	if s.Schedule.Blocked(entry, 0) {
+		return nil, domain.ErrUnreachableExit
+	}
+
+	checker := newContextChecker(ctx)
+
+	start := timedState{point: entry}
+	queue := []timedState{start}
+	visited := map[timedState]bool{start: true}
+	parent := make(map[timedState]timedState)
+
+	for len(queue) > 0 {
+		if err := checker.check(); err != nil {
+			return nil, err
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+
+		// If the exit point is reached, reconstruct the path
+		if current.point == exit {
+			path := []domain.Point{current.point}
+			for state := current; state != start; {
+				state = parent[state]
+				path = append([]domain.Point{state.point}, path...)
+			}
+
+			return path, nil
+		}
+
+		// Wait in place or step to a neighboring passage
+		for _, next := range append([]domain.Point{current.point}, passageNeighbors(maze, current.point)...) {
+			if !s.Schedule.CanMove(current.point, next, current.phase) {
+				continue
+			}
+
+			state := timedState{point: next, phase: (current.phase + 1) % period}
+			if visited[state] {
+				continue
+			}
+
+			visited[state] = true
+			parent[state] = current
+			queue = append(queue, state)
+		}
+	}
+
+	// Path not found
+	return nil, domain.ErrUnreachableExit
+}
@@ -0,0 +1,145 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// assertTimedPath checks that every time step either waits or moves to an adjacent passage
+// without running into a hazard.
+func assertTimedPath(t *testing.T, maze *domain.Maze, schedule domain.Schedule, path []domain.Point, entry, exit domain.Point) {
+	t.Helper()
+
+	if len(path) == 0 || path[0] != entry || path[len(path)-1] != exit {
+		t.Fatalf("Expected path from %v to %v, got %v", entry, exit, path)
+	}
+
+	for step := 1; step < len(path); step++ {
+		from, to := path[step-1], path[step]
+
+		if dx, dy := to.X-from.X, to.Y-from.Y; dx*dx+dy*dy > 1 || maze.Grid[to.Y][to.X].Wall {
+			t.Fatalf("Expected a wait or a step to an adjacent passage, got %v -> %v", from, to)
+		}
+
+		if !schedule.CanMove(from, to, step-1) {
+			t.Fatalf("Expected %v -> %v at step %d to avoid hazards", from, to, step-1)
+		}
+	}
+}
+
+func TestTimedSolver_FindPath_WaitsForGate(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.#",
+		"#.#",
+		"#.#",
+		"#.#",
+		"#.#",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 4}
+
+	// The gate in the middle of the corridor only opens at steps 4 and 5 of every 6
+	schedule := domain.Schedule{&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 2}, Cycle: 6, ClosedFor: 4}}
+
+	path, err := application.NewTimedSolver(schedule).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertTimedPath(t, maze, schedule, path, entry, exit)
+
+	// Four moves and two waits before the gate
+	if len(path) != 7 || path[4] != (domain.Point{X: 1, Y: 2}) {
+		t.Errorf("Expected to pass the gate at step 4 and arrive at step 6, got %v", path)
+	}
+}
+
+func TestTimedSolver_FindPath_AvoidsPatrols(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+
+	for i := 0; i < 30; i++ {
+		maze, entry, exit := newBraidedMaze(t, 15, 20, rng)
+
+		// Guards walk back and forth along random stretches of the solution path
+		solution, err := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var schedule domain.Schedule
+
+		for g := 0; g < 2; g++ {
+			from := 2 + rng.Intn(len(solution)-6)
+			schedule = append(schedule, &domain.Patrol{Route: solution[from : from+2+rng.Intn(4)]})
+		}
+
+		path, err := application.NewTimedSolver(schedule).FindPath(maze, entry, exit)
+		if errors.Is(err, domain.ErrUnreachableExit) {
+			continue // A guard may block a dead-end corridor for good
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertTimedPath(t, maze, schedule, path, entry, exit)
+
+		if len(path) < len(solution) {
+			t.Fatalf("Expected no path shorter than %d cells, got %d", len(solution), len(path))
+		}
+	}
+}
+
+func TestTimedSolver_FindPath_NoHazards(t *testing.T) {
+	maze, entry, exit := newBraidedMaze(t, 21, 30, rand.New(rand.NewSource(18)))
+
+	expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+	path, err := application.NewTimedSolver(nil).FindPath(maze, entry, exit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(path) != len(expected) {
+		t.Errorf("Expected path of %d cells, got %d", len(expected), len(path))
+	}
+}
+
+func TestTimedSolver_FindPath_Errors(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.#",
+		"#.#",
+		"#.#",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 2}
+
+	closed := domain.Schedule{&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 3, ClosedFor: 3}}
+	if _, err := application.NewTimedSolver(closed).FindPath(maze, entry, exit); !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected ErrUnreachableExit, got %v", err)
+	}
+
+	invalid := domain.Schedule{&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}}}
+	if _, err := application.NewTimedSolver(invalid).FindPath(maze, entry, exit); !errors.Is(err, domain.ErrInvalidHazard) {
+		t.Errorf("Expected ErrInvalidHazard, got %v", err)
+	}
+
+	long := domain.Schedule{
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 97, ClosedFor: 1},
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 89, ClosedFor: 1},
+	}
+	if _, err := application.NewTimedSolver(long).FindPath(maze, entry, exit); !errors.Is(err, application.ErrScheduleTooLong) {
+		t.Errorf("Expected ErrScheduleTooLong, got %v", err)
+	}
+
+	// The product of these cycles overflows an int
+	overflowing := domain.Schedule{
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 1 << 62, ClosedFor: 1},
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 3, ClosedFor: 1},
+	}
+	if _, err := application.NewTimedSolver(overflowing).FindPath(maze, entry, exit); !errors.Is(err, application.ErrScheduleTooLong) {
+		t.Errorf("Expected ErrScheduleTooLong, got %v", err)
+	}
+}
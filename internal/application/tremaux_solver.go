@@ -0,0 +1,82 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// TremauxSolver implements Trémaux's algorithm: every passage is marked each time it is
+// walked, dead ends and already visited junctions send the walker back, and no passage is
+// walked more than twice. Unlike the wall follower it works on braided mazes too.
+type TremauxSolver struct{}
+
+// tremauxPassage is an undirected passage between two adjacent cells.
+type tremauxPassage struct{ a, b domain.Point }
+
+func newTremauxPassage(a, b domain.Point) tremauxPassage {
+	if b.Y < a.Y || (b.Y == a.Y && b.X < a.X) {
+		a, b = b, a
+	}
+
+	return tremauxPassage{a, b}
+}
+
+func (s *TremauxSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	path, _, err := s.FindPathWithTrajectory(maze, entry, exit)
+	return path, err
+}
+
+// FindPathWithTrajectory returns the path without detours along with every step walked.
+func (s *TremauxSolver) FindPathWithTrajectory(maze *domain.Maze, entry, exit domain.Point) (path, trajectory []domain.Point, err error) {
+	if err = maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, nil, err
+	}
+
+	marks := make(map[tremauxPassage]int)
+	visited := map[domain.Point]bool{entry: true}
+
+	current, previous := entry, entry
+	trajectory = []domain.Point{entry}
+
+	for current != exit {
+		next, ok := s.choosePassage(maze, marks, current, previous)
+		if !ok {
+			return nil, trajectory, domain.ErrUnreachableExit // Every passage has been walked twice
+		}
+
+		marks[newTremauxPassage(current, next)]++
+		previous, current = current, next
+		trajectory = append(trajectory, current)
+
+		// Remember whether we arrived somewhere new before marking the cell
+		firstVisit := !visited[current]
+		visited[current] = true
+
+		if !firstVisit && current != exit && marks[newTremauxPassage(previous, current)] == 1 {
+			// An already visited cell reached through a new passage: turn back
+			marks[newTremauxPassage(current, previous)]++
+			previous, current = current, previous
+			trajectory = append(trajectory, current)
+		}
+	}
+
+	return eraseLoops(trajectory), trajectory, nil
+}
+
+// choosePassage picks an unmarked passage if there is one, otherwise a passage marked once,
+// preferring not to turn back the way we came.
+func (s *TremauxSolver) choosePassage(maze *domain.Maze, marks map[tremauxPassage]int,
+	current, previous domain.Point) (domain.Point, bool) {
+	var (
+		best      domain.Point
+		bestMarks = 2
+	)
+
+	for _, neighbor := range passageNeighbors(maze, current) {
+		m := marks[newTremauxPassage(current, neighbor)]
+
+		// Fewer marks win; on a tie avoid going back to the previous cell
+		if m < bestMarks || (m == bestMarks && best == previous) {
+			best, bestMarks = neighbor, m
+		}
+	}
+
+	return best, bestMarks < 2
+}
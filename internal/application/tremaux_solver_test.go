@@ -0,0 +1,46 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestTremauxSolver_BraidedMazes(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 20; i++ {
+		maze, entry, exit := newBraidedMaze(t, 21, rng.Intn(60), rng)
+
+		path, trajectory, err := (&application.TremauxSolver{}).FindPathWithTrajectory(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+		assertWalkedTrajectory(t, trajectory, entry, exit)
+	}
+}
+
+func TestTremauxSolver_NoPath(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#...#",
+		"#####",
+		"#...#",
+		"###.#",
+	)
+
+	path, trajectory, err := (&application.TremauxSolver{}).FindPathWithTrajectory(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 4})
+	if path != nil || !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected no path, got %v, %v", path, err)
+	}
+
+	// The walker explores the reachable part and returns to the entry
+	if trajectory[len(trajectory)-1] != (domain.Point{X: 1, Y: 0}) {
+		t.Errorf("Expected the walk to end at the entry, got %v", trajectory[len(trajectory)-1])
+	}
+}
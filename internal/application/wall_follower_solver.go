@@ -0,0 +1,138 @@
+package application
+
+import "github.com/abakunov/mazes/internal/domain"
+
+// Hand selects the wall the WallFollowerSolver keeps touching.
+type Hand int
+
+const (
+	LeftHand Hand = iota
+	RightHand
+)
+
+// clockwiseDirections lists the movement directions clockwise: up, right, down, left.
+var clockwiseDirections = []domain.Point{
+	{X: 0, Y: -1}, // Up
+	{X: 1, Y: 0},  // Right
+	{X: 0, Y: 1},  // Down
+	{X: -1, Y: 0}, // Left
+}
+
+// WallFollowerSolver walks the maze keeping one hand on the wall. It always finds the exit
+// of a perfect maze when both openings are on the border, but may circle forever around
+// an island in a braided maze, in which case ErrUnreachableExit is returned.
+type WallFollowerSolver struct {
+	Hand Hand
+}
+
+func (s *WallFollowerSolver) FindPath(maze *domain.Maze, entry, exit domain.Point) ([]domain.Point, error) {
+	path, _, err := s.FindPathWithTrajectory(maze, entry, exit)
+	return path, err
+}
+
+// FindPathWithTrajectory returns the path without detours along with every step walked.
+func (s *WallFollowerSolver) FindPathWithTrajectory(maze *domain.Maze,
+	entry, exit domain.Point) (path, trajectory []domain.Point, err error) {
+	if err = maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, nil, err
+	}
+
+	type state struct {
+		point     domain.Point
+		direction int
+	}
+
+	// Face the first open neighbor
+	direction := 0
+
+	for i, dir := range clockwiseDirections {
+		if isPassage(maze, domain.Point{X: entry.X + dir.X, Y: entry.Y + dir.Y}) {
+			direction = i
+			break
+		}
+	}
+
+	// Turns relative to the current direction, in the order they are tried
+	turns := []int{3, 0, 1, 2} // Left, straight, right, back
+	if s.Hand == RightHand {
+		turns = []int{1, 0, 3, 2} // Right, straight, left, back
+	}
+
+	current := entry
+	trajectory = []domain.Point{entry}
+	seen := make(map[state]bool)
+
+	for current != exit {
+		// Coming back to the same position facing the same way means we are walking in circles
+		if seen[state{current, direction}] {
+			return nil, trajectory, domain.ErrUnreachableExit
+		}
+
+		seen[state{current, direction}] = true
+
+		moved := false
+
+		for _, turn := range turns {
+			next := (direction + turn) % 4
+			dir := clockwiseDirections[next]
+			neighbor := domain.Point{X: current.X + dir.X, Y: current.Y + dir.Y}
+
+			if isPassage(maze, neighbor) {
+				current, direction, moved = neighbor, next, true
+				break
+			}
+		}
+
+		if !moved {
+			return nil, trajectory, domain.ErrUnreachableExit // Enclosed entry
+		}
+
+		trajectory = append(trajectory, current)
+	}
+
+	return eraseLoops(trajectory), trajectory, nil
+}
+
+// eraseLoops removes detours from a walked trajectory: whenever a cell is visited again,
+// everything walked since its previous visit is dropped.
+func eraseLoops(trajectory []domain.Point) []domain.Point {
+	var path []domain.Point
+
+	index := make(map[domain.Point]int)
+
+	for _, p := range trajectory {
+		if i, ok := index[p]; ok {
+			for _, dropped := range path[i+1:] {
+				delete(index, dropped)
+			}
+
+			path = path[:i+1]
+
+			continue
+		}
+
+		index[p] = len(path)
+		path = append(path, p)
+	}
+
+	return path
+}
+
+// isPassage checks that the point is within the maze bounds and is not a wall.
+func isPassage(maze *domain.Maze, p domain.Point) bool {
+	return p.X >= 0 && p.X < maze.Width && p.Y >= 0 && p.Y < maze.Height && !maze.Grid[p.Y][p.X].Wall
+}
+
+// passageNeighbors returns the passage cells adjacent to the point.
+func passageNeighbors(maze *domain.Maze, p domain.Point) []domain.Point {
+	var neighbors []domain.Point
+
+	for _, dir := range clockwiseDirections {
+		n := domain.Point{X: p.X + dir.X, Y: p.Y + dir.Y}
+		if isPassage(maze, n) {
+			neighbors = append(neighbors, n)
+		}
+	}
+
+	return neighbors
+}
@@ -0,0 +1,70 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// assertWalkedTrajectory checks that the trajectory is a continuous walk from entry to exit.
+func assertWalkedTrajectory(t *testing.T, trajectory []domain.Point, entry, exit domain.Point) {
+	t.Helper()
+
+	if len(trajectory) == 0 || trajectory[0] != entry || trajectory[len(trajectory)-1] != exit {
+		t.Fatalf("Expected trajectory from %v to %v", entry, exit)
+	}
+
+	for i := 1; i < len(trajectory); i++ {
+		dx, dy := trajectory[i].X-trajectory[i-1].X, trajectory[i].Y-trajectory[i-1].Y
+		if dx*dx+dy*dy != 1 {
+			t.Fatalf("Trajectory jumps from %v to %v", trajectory[i-1], trajectory[i])
+		}
+	}
+}
+
+func TestWallFollowerSolver_PerfectMaze(t *testing.T) {
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 19, Y: 20}
+
+	maze := domain.NewMaze(21, 21)
+	if err := (&application.KruskalGenerator{}).Generate(maze, entry, exit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+
+	for _, hand := range []application.Hand{application.LeftHand, application.RightHand} {
+		path, trajectory, err := (&application.WallFollowerSolver{Hand: hand}).FindPathWithTrajectory(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertValidPath(t, maze, path, entry, exit)
+		assertWalkedTrajectory(t, trajectory, entry, exit)
+
+		// A perfect maze has exactly one simple path
+		if len(path) != len(expected) {
+			t.Errorf("Expected path of length %d, got %d", len(expected), len(path))
+		}
+	}
+}
+
+func TestWallFollowerSolver_ExitOnIsland(t *testing.T) {
+	// The exit is a notch in the pillar in the middle, the left hand follows the outer wall forever
+	maze := newMazeFromRows(
+		"#.#####",
+		"#.....#",
+		"#.#.#.#",
+		"#.###.#",
+		"#.###.#",
+		"#.....#",
+		"#######",
+	)
+
+	path, err := (&application.WallFollowerSolver{}).FindPath(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 2})
+	if path != nil || !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected the wall follower to give up, got %v, %v", path, err)
+	}
+}
@@ -0,0 +1,282 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+const (
+	// defaultExactWaypoints is the largest number of waypoints ordered exactly when no limit is set.
+	defaultExactWaypoints = 10
+	// unreachableCost stands for a missing path between two points, large enough to never be chosen
+	// over a real path yet small enough to add up without overflowing.
+	unreachableCost = math.MaxInt32
+)
+
+// WaypointSolver finds a route from the entry through every waypoint to the exit. Shortest
+// paths between all pairs of points are found with the underlying solver; the order of the
+// waypoints is then chosen exactly with Held-Karp dynamic programming for small counts, and
+// with nearest neighbour improved by 2-opt for larger ones.
+type WaypointSolver struct {
+	// Solver finds the paths between pairs of points; BFS is used if nil.
+	Solver domain.Solver
+	// ExactLimit is the largest number of waypoints ordered exactly; 10 is used if zero.
+	ExactLimit int
+}
+
+// NewWaypointSolver initializes the WaypointSolver.
+func NewWaypointSolver(solver domain.Solver) *WaypointSolver {
+	return &WaypointSolver{Solver: solver}
+}
+
+// FindRoute returns the full path from the entry through all waypoints to the exit.
+func (s *WaypointSolver) FindRoute(maze *domain.Maze, entry, exit domain.Point, waypoints []domain.Point) ([]domain.Point, error) {
+	return s.FindRouteContext(context.Background(), maze, entry, exit, waypoints)
+}
+
+// FindRouteContext finds the route, stopping early if the context is done.
+func (s *WaypointSolver) FindRouteContext(ctx context.Context, maze *domain.Maze, entry, exit domain.Point,
+	waypoints []domain.Point) ([]domain.Point, error) {
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		return nil, err
+	}
+
+	if err := maze.ValidateWaypoints(waypoints); err != nil {
+		return nil, err
+	}
+
+	// Points are the entry, the waypoints and the exit, in this order
+	points := append(append([]domain.Point{entry}, waypoints...), exit)
+
+	paths, err := s.pairwisePaths(ctx, maze, points)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := make([][]int, len(points))
+	for i := range points {
+		cost[i] = make([]int, len(points))
+
+		for j := range points {
+			if paths[i][j] != nil {
+				cost[i][j] = len(paths[i][j]) - 1
+			} else if i != j {
+				cost[i][j] = unreachableCost
+			}
+		}
+	}
+
+	var order []int
+	if len(waypoints) <= s.exactLimit() {
+		order = s.exactOrder(cost)
+	} else {
+		order = s.improveOrder(cost, s.nearestNeighborOrder(cost))
+	}
+
+	if s.routeCost(cost, order) >= unreachableCost {
+		for j, p := range waypoints {
+			if cost[0][j+1] >= unreachableCost {
+				return nil, &domain.PointError{Name: "waypoint", Point: p, Err: domain.ErrUnreachableExit}
+			}
+		}
+
+		return nil, domain.ErrUnreachableExit
+	}
+
+	// Stitch the segments, dropping the point shared by consecutive segments
+	route := []domain.Point{entry}
+	previous := 0
+
+	for _, next := range append(order, len(points)-1) {
+		route = append(route, paths[previous][next][1:]...)
+		previous = next
+	}
+
+	return route, nil
+}
+
+// pairwisePaths finds the shortest paths between every pair of points, leaving nil where there
+// is none. Paths are searched in both directions since one-way passages may make them differ.
+func (s *WaypointSolver) pairwisePaths(ctx context.Context, maze *domain.Maze, points []domain.Point) ([][][]domain.Point, error) {
+	last := len(points) - 1
+	paths := make([][][]domain.Point, len(points))
+
+	for i := range points {
+		paths[i] = make([][]domain.Point, len(points))
+
+		// Nothing leaves the exit
+		if i == last {
+			continue
+		}
+
+		for j := 1; j < len(points); j++ {
+			if i == j || (i == 0 && j == last && last > 1) {
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			path, err := s.findPath(ctx, maze, points[i], points[j])
+			if err != nil && !errors.Is(err, domain.ErrUnreachableExit) {
+				return nil, err
+			}
+
+			paths[i][j] = path
+		}
+	}
+
+	return paths, nil
+}
+
+// findPath runs the underlying solver, passing the context along if the solver supports it.
+func (s *WaypointSolver) findPath(ctx context.Context, maze *domain.Maze, from, to domain.Point) ([]domain.Point, error) {
+	switch solver := s.Solver.(type) {
+	case nil:
+		return (&BFSSolver{}).FindPathContext(ctx, maze, from, to)
+	case domain.ContextSolver:
+		return solver.FindPathContext(ctx, maze, from, to)
+	default:
+		return solver.FindPath(maze, from, to)
+	}
+}
+
+func (s *WaypointSolver) exactLimit() int {
+	if s.ExactLimit > 0 {
+		return s.ExactLimit
+	}
+
+	return defaultExactWaypoints
+}
+
+// exactOrder returns the cheapest order of the waypoints using Held-Karp dynamic programming
+// over subsets of visited waypoints. Waypoints are numbered from 1, the exit is the last point.
+func (s *WaypointSolver) exactOrder(cost [][]int) []int {
+	n := len(cost) - 2
+	if n == 0 {
+		return nil
+	}
+
+	full := 1<<n - 1
+
+	// best[mask][i] is the cheapest way to visit the waypoints in mask ending at waypoint i
+	best := make([][]int, full+1)
+	from := make([][]int, full+1)
+
+	for mask := range best {
+		best[mask] = make([]int, n)
+		from[mask] = make([]int, n)
+
+		for i := range best[mask] {
+			best[mask][i] = math.MaxInt
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		best[1<<i][i] = cost[0][i+1]
+		from[1<<i][i] = -1
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) == 0 || best[mask][i] == math.MaxInt {
+				continue
+			}
+
+			for j := 0; j < n; j++ {
+				if mask&(1<<j) != 0 {
+					continue
+				}
+
+				next := mask | 1<<j
+				if c := best[mask][i] + cost[i+1][j+1]; c < best[next][j] {
+					best[next][j] = c
+					from[next][j] = i
+				}
+			}
+		}
+	}
+
+	// Pick the last waypoint including the way to the exit
+	last := 0
+	for i := 1; i < n; i++ {
+		if best[full][i]+cost[i+1][n+1] < best[full][last]+cost[last+1][n+1] {
+			last = i
+		}
+	}
+
+	order := make([]int, n)
+	for mask, i, k := full, last, n-1; i >= 0; k-- {
+		order[k] = i + 1
+		mask, i = mask&^(1<<i), from[mask][i]
+	}
+
+	return order
+}
+
+// nearestNeighborOrder visits the closest unvisited waypoint each time, starting from the entry.
+func (s *WaypointSolver) nearestNeighborOrder(cost [][]int) []int {
+	n := len(cost) - 2
+	visited := make([]bool, n+1)
+	order := make([]int, 0, n)
+
+	for current := 0; len(order) < n; {
+		next := -1
+
+		for j := 1; j <= n; j++ {
+			if !visited[j] && (next < 0 || cost[current][j] < cost[current][next]) {
+				next = j
+			}
+		}
+
+		visited[next] = true
+		order = append(order, next)
+		current = next
+	}
+
+	return order
+}
+
+// improveOrder applies 2-opt: segments of the order are reversed as long as that makes the route cheaper.
+func (s *WaypointSolver) improveOrder(cost [][]int, order []int) []int {
+	bestCost := s.routeCost(cost, order)
+
+	for improved := true; improved; {
+		improved = false
+
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				reverse(order[i : j+1])
+
+				if c := s.routeCost(cost, order); c < bestCost {
+					bestCost, improved = c, true
+				} else {
+					reverse(order[i : j+1])
+				}
+			}
+		}
+	}
+
+	return order
+}
+
+// routeCost returns the length of the route from the entry through the waypoints in order to the exit.
+func (s *WaypointSolver) routeCost(cost [][]int, order []int) int {
+	total, previous := 0, 0
+	for _, next := range order {
+		total += cost[previous][next]
+		previous = next
+	}
+
+	return total + cost[previous][len(cost)-1]
+}
+
+func reverse(order []int) {
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+}
@@ -0,0 +1,178 @@
+package application_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// randomPassages picks distinct passage cells of the maze other than the excluded ones,
+// connected to the first excluded cell.
+func randomPassages(maze *domain.Maze, count int, rng *rand.Rand, excluded ...domain.Point) []domain.Point {
+	taken := make(map[domain.Point]bool)
+	for _, p := range excluded {
+		taken[p] = true
+	}
+
+	var points []domain.Point
+
+	for len(points) < count {
+		p := domain.Point{X: rng.Intn(maze.Width), Y: rng.Intn(maze.Height)}
+		if maze.Grid[p.Y][p.X].Wall || taken[p] {
+			continue
+		}
+
+		taken[p] = true
+
+		if _, err := (&application.BFSSolver{}).FindPath(maze, p, excluded[0]); err == nil {
+			points = append(points, p)
+		}
+	}
+
+	return points
+}
+
+// bruteForceRouteLength tries every order of the waypoints and returns the shortest route length.
+func bruteForceRouteLength(t *testing.T, maze *domain.Maze, entry, exit domain.Point, waypoints []domain.Point) int {
+	t.Helper()
+
+	distances := make(map[[2]domain.Point]int)
+	distance := func(a, b domain.Point) int {
+		if d, ok := distances[[2]domain.Point{a, b}]; ok {
+			return d
+		}
+
+		path, err := (&application.BFSSolver{}).FindPath(maze, a, b)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		distances[[2]domain.Point{a, b}] = len(path) - 1
+
+		return len(path) - 1
+	}
+
+	best := -1
+
+	var permute func(current domain.Point, remaining []domain.Point, length int)
+	permute = func(current domain.Point, remaining []domain.Point, length int) {
+		if len(remaining) == 0 {
+			if total := length + distance(current, exit); best < 0 || total < best {
+				best = total
+			}
+
+			return
+		}
+
+		for i, next := range remaining {
+			rest := append(append([]domain.Point{}, remaining[:i]...), remaining[i+1:]...)
+			permute(next, rest, length+distance(current, next))
+		}
+	}
+
+	permute(entry, waypoints, 0)
+
+	return best
+}
+
+// assertRoute checks that the route is walkable and visits every waypoint.
+func assertRoute(t *testing.T, maze *domain.Maze, route []domain.Point, entry, exit domain.Point, waypoints []domain.Point) {
+	t.Helper()
+
+	assertValidPath(t, maze, route, entry, exit)
+
+	onRoute := make(map[domain.Point]bool)
+	for _, p := range route {
+		onRoute[p] = true
+	}
+
+	for _, w := range waypoints {
+		if !onRoute[w] {
+			t.Fatalf("Expected the route to visit waypoint %v", w)
+		}
+	}
+}
+
+func TestWaypointSolver_FindRoute_Exact(t *testing.T) {
+	rng := rand.New(rand.NewSource(12))
+
+	for i := 0; i < 20; i++ {
+		maze, entry, exit := newBraidedMaze(t, 21, 40, rng)
+		waypoints := randomPassages(maze, 5, rng, entry, exit)
+
+		route, err := application.NewWaypointSolver(&application.AStarSolver{}).FindRoute(maze, entry, exit, waypoints)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertRoute(t, maze, route, entry, exit, waypoints)
+
+		if expected := bruteForceRouteLength(t, maze, entry, exit, waypoints); len(route)-1 != expected {
+			t.Fatalf("Expected route of %d moves, got %d", expected, len(route)-1)
+		}
+	}
+}
+
+func TestWaypointSolver_FindRoute_Heuristic(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+
+	for i := 0; i < 10; i++ {
+		maze, entry, exit := newBraidedMaze(t, 21, 40, rng)
+		waypoints := randomPassages(maze, 6, rng, entry, exit)
+
+		solver := &application.WaypointSolver{ExactLimit: 2}
+
+		route, err := solver.FindRoute(maze, entry, exit, waypoints)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertRoute(t, maze, route, entry, exit, waypoints)
+
+		if expected := bruteForceRouteLength(t, maze, entry, exit, waypoints); len(route)-1 < expected {
+			t.Fatalf("Expected route of at least %d moves, got %d", expected, len(route)-1)
+		}
+	}
+}
+
+func TestWaypointSolver_FindRoute_NoWaypoints(t *testing.T) {
+	maze, entry, exit := newBraidedMaze(t, 11, 0, rand.New(rand.NewSource(14)))
+
+	route, err := application.NewWaypointSolver(nil).FindRoute(maze, entry, exit, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected, _ := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+	if len(route) != len(expected) {
+		t.Errorf("Expected route of %d cells, got %d", len(expected), len(route))
+	}
+}
+
+func TestWaypointSolver_FindRoute_InvalidWaypoints(t *testing.T) {
+	maze := newMazeFromRows(
+		"#.###",
+		"#...#",
+		"###.#",
+		"#.#.#",
+		"###.#",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 4}
+	solver := application.NewWaypointSolver(&application.BFSSolver{})
+
+	_, err := solver.FindRoute(maze, entry, exit, []domain.Point{{X: 0, Y: 0}})
+	if !errors.Is(err, domain.ErrPointInWall) {
+		t.Errorf("Expected ErrPointInWall, got %v", err)
+	}
+
+	// An enclosed cell cannot be visited
+	_, err = solver.FindRoute(maze, entry, exit, []domain.Point{{X: 2, Y: 1}, {X: 1, Y: 3}})
+
+	var pointErr *domain.PointError
+	if !errors.As(err, &pointErr) || pointErr.Point != (domain.Point{X: 1, Y: 3}) || !errors.Is(err, domain.ErrUnreachableExit) {
+		t.Errorf("Expected unreachable waypoint (1, 3), got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MinMazeSize is the smallest width and height a maze can be generated with.
+const MinMazeSize = 3
+
+var (
+	// ErrInvalidDimensions is returned for mazes that are too small or whose grid does not match their size.
+	ErrInvalidDimensions = errors.New("invalid maze dimensions")
+	// ErrPointOutOfBounds is returned when the entry or exit lies outside of the grid.
+	ErrPointOutOfBounds = errors.New("point is outside of the maze")
+	// ErrInvalidOpening is returned when the entry or exit of a generated maze is a corner or lies off the border.
+	ErrInvalidOpening = errors.New("opening must lie on the border, corners excluded")
+	// ErrPointInWall is returned when the entry or exit of a solved maze is a wall.
+	ErrPointInWall = errors.New("point is inside a wall")
+	// ErrUnreachableExit is returned when there is no path from the entry to the exit.
+	ErrUnreachableExit = errors.New("exit is unreachable from entry")
+	// ErrInvalidItemColor is returned for keys and doors whose color is outside [0, MaxItemColors).
+	ErrInvalidItemColor = errors.New("item color is out of range")
+)
+
+// DimensionsError describes a maze with invalid dimensions.
+type DimensionsError struct {
+	Width  int
+	Height int
+}
+
+func (e *DimensionsError) Error() string {
+	return fmt.Sprintf("%v: %dx%d (minimum %dx%d)", ErrInvalidDimensions, e.Width, e.Height, MinMazeSize, MinMazeSize)
+}
+
+func (e *DimensionsError) Unwrap() error {
+	return ErrInvalidDimensions
+}
+
+// PointError describes an invalid entry or exit point.
+type PointError struct {
+	Name  string
+	Point Point
+	Err   error
+}
+
+func (e *PointError) Error() string {
+	return fmt.Sprintf("%s (%d, %d): %v", e.Name, e.Point.X, e.Point.Y, e.Err)
+}
+
+func (e *PointError) Unwrap() error {
+	return e.Err
+}
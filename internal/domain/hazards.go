@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidHazard is returned for gates with an invalid cycle and patrols with a broken route.
+var ErrInvalidHazard = errors.New("invalid hazard")
+
+// Hazard blocks cells of the maze at certain time steps.
+type Hazard interface {
+	// Blocks checks whether the cell is blocked at the time step.
+	Blocks(p Point, t int) bool
+	// Moves checks whether the hazard itself moves from one cell to the other between
+	// time steps t and t+1, so that nothing can pass it in the opposite direction.
+	Moves(from, to Point, t int) bool
+	// Period is the number of time steps after which the hazard repeats itself.
+	Period() int
+	// Validate checks that the hazard fits into the maze.
+	Validate(m *Maze) error
+}
+
+// PeriodicGate is a cell that is closed for the first ClosedFor steps of every cycle of Cycle
+// steps. Offset shifts the cycle so that several gates can open one after another.
+type PeriodicGate struct {
+	Cell      Point
+	Cycle     int
+	ClosedFor int
+	Offset    int
+}
+
+func (g *PeriodicGate) Blocks(p Point, t int) bool {
+	return p == g.Cell && ((t+g.Offset)%g.Cycle+g.Cycle)%g.Cycle < g.ClosedFor
+}
+
+func (g *PeriodicGate) Moves(_, _ Point, _ int) bool {
+	return false
+}
+
+func (g *PeriodicGate) Period() int {
+	return g.Cycle
+}
+
+func (g *PeriodicGate) Validate(m *Maze) error {
+	if err := m.validatePassage("gate", g.Cell); err != nil {
+		return err
+	}
+
+	if g.Cycle <= 0 || g.ClosedFor < 0 || g.ClosedFor > g.Cycle {
+		return &PointError{Name: "gate", Point: g.Cell, Err: ErrInvalidHazard}
+	}
+
+	return nil
+}
+
+// Patrol is a guard walking its route one cell per time step. A looped route is walked round
+// and round, otherwise the guard turns back at each end.
+type Patrol struct {
+	Route []Point
+	Loop  bool
+}
+
+// Position returns the cell the guard is in at the time step.
+func (p *Patrol) Position(t int) Point {
+	period := p.Period()
+	i := (t%period + period) % period
+
+	if i >= len(p.Route) {
+		i = period - i // Walking back
+	}
+
+	return p.Route[i]
+}
+
+func (p *Patrol) Blocks(cell Point, t int) bool {
+	return p.Position(t) == cell
+}
+
+func (p *Patrol) Moves(from, to Point, t int) bool {
+	return p.Position(t) == from && p.Position(t+1) == to
+}
+
+func (p *Patrol) Period() int {
+	switch {
+	case len(p.Route) <= 1:
+		return 1
+	case p.Loop:
+		return len(p.Route)
+	default:
+		return 2 * (len(p.Route) - 1)
+	}
+}
+
+func (p *Patrol) Validate(m *Maze) error {
+	if len(p.Route) == 0 {
+		return ErrInvalidHazard
+	}
+
+	for i, cell := range p.Route {
+		if err := m.validatePassage("patrol", cell); err != nil {
+			return err
+		}
+
+		if i > 0 && !adjacent(p.Route[i-1], cell) {
+			return &PointError{Name: "patrol", Point: cell, Err: ErrInvalidHazard}
+		}
+	}
+
+	if p.Loop && len(p.Route) > 1 && !adjacent(p.Route[len(p.Route)-1], p.Route[0]) {
+		return &PointError{Name: "patrol", Point: p.Route[0], Err: ErrInvalidHazard}
+	}
+
+	return nil
+}
+
+// Schedule is the set of hazards of a time-dependent maze.
+type Schedule []Hazard
+
+// Blocked checks whether any hazard blocks the cell at the time step.
+func (s Schedule) Blocked(p Point, t int) bool {
+	for _, h := range s {
+		if h.Blocks(p, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanMove checks that moving from one cell to an adjacent one, or waiting when both are the same,
+// between time steps t and t+1 neither ends in a blocked cell nor passes a hazard head-on.
+func (s Schedule) CanMove(from, to Point, t int) bool {
+	if s.Blocked(to, t+1) {
+		return false
+	}
+
+	for _, h := range s {
+		if from != to && h.Moves(to, from, t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Period returns the number of time steps after which the whole schedule repeats, or math.MaxInt
+// if that does not fit into an int.
+func (s Schedule) Period() int {
+	period, ok := s.PeriodWithin(math.MaxInt)
+	if !ok {
+		return math.MaxInt
+	}
+
+	return period
+}
+
+// PeriodWithin returns the period of the schedule if it does not exceed the limit. It stops as soon
+// as the period of the hazards seen so far grows over the limit, so it never overflows.
+func (s Schedule) PeriodWithin(limit int) (int, bool) {
+	period := 1
+
+	for _, h := range s {
+		var ok bool
+		if period, ok = lcmWithin(period, h.Period(), limit); !ok {
+			return 0, false
+		}
+	}
+
+	return period, true
+}
+
+// Validate checks every hazard of the schedule.
+func (s Schedule) Validate(m *Maze) error {
+	for _, h := range s {
+		if err := h.Validate(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func adjacent(a, b Point) bool {
+	return abs(a.X-b.X)+abs(a.Y-b.Y) == 1
+}
+
+// lcmWithin returns the least common multiple of two positive numbers if it does not exceed the limit.
+func lcmWithin(a, b, limit int) (int, bool) {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+
+	if a/x > limit/b {
+		return 0, false
+	}
+
+	return a / x * b, true
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestPeriodicGate_Blocks(t *testing.T) {
+	cell := domain.Point{X: 1, Y: 1}
+	gate := &domain.PeriodicGate{Cell: cell, Cycle: 4, ClosedFor: 1, Offset: 1}
+
+	// Closed whenever (t+1) % 4 == 0
+	for step, closed := range []bool{false, false, false, true, false, false, false, true} {
+		if gate.Blocks(cell, step) != closed {
+			t.Errorf("Expected gate closed=%v at step %d", closed, step)
+		}
+	}
+
+	if gate.Blocks(domain.Point{X: 2, Y: 1}, 3) {
+		t.Error("Expected the gate to block only its own cell")
+	}
+}
+
+func TestPatrol_Position(t *testing.T) {
+	route := []domain.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}}
+
+	// Back and forth: 0 1 2 1 0 1 ...
+	patrol := &domain.Patrol{Route: route}
+	for step, i := range []int{0, 1, 2, 1, 0, 1, 2} {
+		if p := patrol.Position(step); p != route[i] {
+			t.Errorf("Expected guard at %v at step %d, got %v", route[i], step, p)
+		}
+	}
+
+	if !patrol.Moves(route[1], route[2], 1) || patrol.Moves(route[2], route[1], 1) {
+		t.Error("Expected the guard to move from the second to the third cell at step 1")
+	}
+
+	// Round and round: 0 1 2 0 1 ...
+	loop := &domain.Patrol{Route: []domain.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}}, Loop: true}
+	if loop.Period() != 4 || loop.Position(5) != (domain.Point{X: 2, Y: 1}) {
+		t.Errorf("Expected a looped patrol of period 4, got %d", loop.Period())
+	}
+}
+
+func TestSchedule_PeriodAndValidate(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+	maze.Grid[3][3].Wall = true
+
+	schedule := domain.Schedule{
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 4, ClosedFor: 2},
+		&domain.Patrol{Route: []domain.Point{{X: 1, Y: 2}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 4, Y: 2}}},
+	}
+
+	if err := schedule.Validate(maze); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if period := schedule.Period(); period != 12 {
+		t.Errorf("Expected period 12, got %d", period)
+	}
+
+	if _, ok := schedule.PeriodWithin(11); ok {
+		t.Error("Expected period 12 to exceed the limit of 11")
+	}
+
+	overflowing := domain.Schedule{
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 1 << 62, ClosedFor: 1},
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}, Cycle: 3, ClosedFor: 1},
+	}
+	if period := overflowing.Period(); period != math.MaxInt {
+		t.Errorf("Expected an overflowing period to saturate at math.MaxInt, got %d", period)
+	}
+
+	invalid := []domain.Hazard{
+		&domain.PeriodicGate{Cell: domain.Point{X: 1, Y: 1}},
+		&domain.Patrol{Route: []domain.Point{{X: 1, Y: 1}, {X: 3, Y: 1}}},
+		&domain.Patrol{},
+	}
+
+	for _, h := range invalid {
+		if err := h.Validate(maze); !errors.Is(err, domain.ErrInvalidHazard) {
+			t.Errorf("Expected ErrInvalidHazard for %+v, got %v", h, err)
+		}
+	}
+
+	wall := &domain.PeriodicGate{Cell: domain.Point{X: 3, Y: 3}, Cycle: 2, ClosedFor: 1}
+	if err := wall.Validate(maze); !errors.Is(err, domain.ErrPointInWall) {
+		t.Errorf("Expected ErrPointInWall, got %v", err)
+	}
+}
+
+func TestSchedule_CanMove_HeadOn(t *testing.T) {
+	a, b, c := domain.Point{X: 1, Y: 1}, domain.Point{X: 2, Y: 1}, domain.Point{X: 3, Y: 1}
+	schedule := domain.Schedule{&domain.Patrol{Route: []domain.Point{b, a, {X: 0, Y: 1}}}}
+
+	// The guard walks from b to a between steps 0 and 1, so nobody can walk from a to b
+	if schedule.CanMove(a, b, 0) {
+		t.Error("Expected passing the guard head-on to be forbidden")
+	}
+
+	// Waiting in a while the guard is heading there is forbidden too
+	if schedule.CanMove(a, a, 0) {
+		t.Error("Expected waiting in the cell the guard enters to be forbidden")
+	}
+
+	// Stepping into the cell the guard has just left is fine
+	if !schedule.CanMove(c, b, 0) {
+		t.Error("Expected following the guard to be allowed")
+	}
+}
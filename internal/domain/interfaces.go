@@ -1,11 +1,39 @@
 package domain
 
+import "context"
+
 // Generator describes the interface for maze generation.
 type Generator interface {
-	Generate(maze *Maze, entryPoint, exitPoint Point)
+	Generate(maze *Maze, entryPoint, exitPoint Point) error
 }
 
 // Solver describes the interface for finding a path in the maze.
+// ErrUnreachableExit is returned when there is no path.
 type Solver interface {
-	FindPath(maze *Maze, entryPoint, exitPoint Point) []Point
+	FindPath(maze *Maze, entryPoint, exitPoint Point) ([]Point, error)
+}
+
+// TrajectorySolver describes solvers that also report every step taken while searching,
+// including backtracking, the way a person walking the maze would.
+type TrajectorySolver interface {
+	Solver
+	FindPathWithTrajectory(maze *Maze, entryPoint, exitPoint Point) (path, trajectory []Point, err error)
+}
+
+// InstrumentedSolver describes solvers that report how much work the search took.
+type InstrumentedSolver interface {
+	Solver
+	FindPathWithStats(maze *Maze, entryPoint, exitPoint Point) ([]Point, SearchStats, error)
+}
+
+// ContextGenerator describes generators that stop when the context is canceled or its deadline expires.
+type ContextGenerator interface {
+	Generator
+	GenerateContext(ctx context.Context, maze *Maze, entryPoint, exitPoint Point) error
+}
+
+// ContextSolver describes solvers that stop when the context is canceled or its deadline expires.
+type ContextSolver interface {
+	Solver
+	FindPathContext(ctx context.Context, maze *Maze, entryPoint, exitPoint Point) ([]Point, error)
 }
@@ -0,0 +1,44 @@
+package domain
+
+// MaxItemColors is the number of distinct key and door colors a maze can hold.
+const MaxItemColors = 32
+
+// ItemKind describes what lies in a cell.
+type ItemKind int
+
+const (
+	NoItem ItemKind = iota
+	KeyItem
+	DoorItem
+)
+
+// Item is a key or a door of a given color. A door can only be passed while holding
+// the key of the same color; keys are picked up by stepping on them.
+type Item struct {
+	Kind  ItemKind
+	Color int
+}
+
+// ItemAt returns the item lying in the cell, NoItem if there is none.
+func (m *Maze) ItemAt(p Point) Item {
+	return m.Items[p]
+}
+
+// SetItem puts the item into the cell; an item of kind NoItem empties the cell.
+func (m *Maze) SetItem(p Point, item Item) {
+	if item.Kind == NoItem {
+		delete(m.Items, p)
+		return
+	}
+
+	if m.Items == nil {
+		m.Items = make(map[Point]Item)
+	}
+
+	m.Items[p] = item
+}
+
+// ClearItems removes all keys and doors from the maze.
+func (m *Maze) ClearItems() {
+	m.Items = nil
+}
@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidMaze is returned when a maze breaks one of its structural invariants.
+var ErrInvalidMaze = errors.New("maze breaks structural invariants")
+
+// MazeCheck selects the invariants checked by the MazeValidator.
+type MazeCheck uint8
+
+const (
+	// CheckBorders requires the border to be walls except for the entry and exit.
+	CheckBorders MazeCheck = 1 << iota
+	// CheckReachability requires the exit and every open cell to be reachable from the entry.
+	CheckReachability
+	// CheckLoops requires the open cells to form a tree, so there is exactly one path between any two of them.
+	CheckLoops
+	// CheckAlignment requires cells on odd coordinates to be open and pillars on even coordinates inside
+	// the border to be walls, as in mazes with walls between cells.
+	CheckAlignment
+
+	// AllChecks enables every check of a perfect maze.
+	AllChecks = CheckBorders | CheckReachability | CheckLoops | CheckAlignment
+)
+
+// ViolationKind describes which invariant a maze breaks.
+type ViolationKind int
+
+const (
+	// BorderOpening is an open border cell that is neither the entry nor the exit.
+	BorderOpening ViolationKind = iota
+	// UnreachableExit is an exit that cannot be reached from the entry.
+	UnreachableExit
+	// IsolatedRegion is a group of open cells that cannot be reached from the entry.
+	IsolatedRegion
+	// Loop is a passage closing a cycle; removing one wall per loop would make the maze perfect.
+	Loop
+	// Misaligned is a closed cell on odd coordinates or an open pillar on even coordinates.
+	Misaligned
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case BorderOpening:
+		return "border opening"
+	case UnreachableExit:
+		return "unreachable exit"
+	case IsolatedRegion:
+		return "isolated region"
+	case Loop:
+		return "loop"
+	case Misaligned:
+		return "misaligned cell"
+	default:
+		return fmt.Sprintf("violation %d", int(k))
+	}
+}
+
+// Violation is a single broken invariant. Cells holds the size of an isolated region.
+type Violation struct {
+	Kind  ViolationKind
+	Point Point
+	Cells int
+}
+
+func (v Violation) String() string {
+	if v.Kind == IsolatedRegion {
+		return fmt.Sprintf("%v of %d cells at (%d, %d)", v.Kind, v.Cells, v.Point.X, v.Point.Y)
+	}
+
+	return fmt.Sprintf("%v at (%d, %d)", v.Kind, v.Point.X, v.Point.Y)
+}
+
+// ValidationReport lists the violations found in a maze.
+type ValidationReport struct {
+	Violations []Violation
+}
+
+// Valid reports whether the maze keeps every checked invariant.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Has reports whether the maze breaks the invariant of the given kind.
+func (r *ValidationReport) Has(kind ViolationKind) bool {
+	return r.Count(kind) > 0
+}
+
+// Count returns the number of violations of the given kind.
+func (r *ValidationReport) Count(kind ViolationKind) int {
+	count := 0
+
+	for _, v := range r.Violations {
+		if v.Kind == kind {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Err returns nil for a valid maze, otherwise an error wrapping ErrInvalidMaze that lists the violations.
+func (r *ValidationReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+
+	const shown = 5
+
+	descriptions := make([]string, 0, shown)
+	for i := 0; i < len(r.Violations) && i < shown; i++ {
+		descriptions = append(descriptions, r.Violations[i].String())
+	}
+
+	if len(r.Violations) > shown {
+		descriptions = append(descriptions, fmt.Sprintf("and %d more", len(r.Violations)-shown))
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidMaze, strings.Join(descriptions, ", "))
+}
+
+// MazeValidator checks the structural invariants of generated mazes.
+type MazeValidator struct {
+	Checks MazeCheck
+}
+
+// NewMazeValidator initializes the MazeValidator with the checks to run.
+func NewMazeValidator(checks MazeCheck) *MazeValidator {
+	return &MazeValidator{Checks: checks}
+}
+
+// Validate checks the maze with the given entry and exit and reports every violation found.
+func (v *MazeValidator) Validate(maze *Maze, entry, exit Point) *ValidationReport {
+	report := &ValidationReport{}
+
+	if err := maze.ValidateSolvePoints(entry, exit); err != nil {
+		// Without passable openings only the exit can be blamed, the grid is not inspected
+		report.Violations = append(report.Violations, Violation{Kind: UnreachableExit, Point: exit})
+		return report
+	}
+
+	if v.Checks&CheckBorders != 0 {
+		v.checkBorders(maze, entry, exit, report)
+	}
+
+	if v.Checks&CheckAlignment != 0 {
+		v.checkAlignment(maze, report)
+	}
+
+	if v.Checks&(CheckReachability|CheckLoops) != 0 {
+		v.checkRegions(maze, entry, exit, report)
+	}
+
+	return report
+}
+
+// checkBorders reports open border cells other than the entry and exit.
+func (v *MazeValidator) checkBorders(maze *Maze, entry, exit Point, report *ValidationReport) {
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			p := Point{X: x, Y: y}
+			if (x == 0 || y == 0 || x == maze.Width-1 || y == maze.Height-1) && !maze.Grid[y][x].Wall && p != entry && p != exit {
+				report.Violations = append(report.Violations, Violation{Kind: BorderOpening, Point: p})
+			}
+		}
+	}
+}
+
+// checkAlignment reports closed cells and open pillars inside the border.
+func (v *MazeValidator) checkAlignment(maze *Maze, report *ValidationReport) {
+	for y := 1; y < maze.Height-1; y++ {
+		for x := 1; x < maze.Width-1; x++ {
+			cell := x%2 == 1 && y%2 == 1
+			pillar := x%2 == 0 && y%2 == 0
+
+			if (cell && maze.Grid[y][x].Wall) || (pillar && !maze.Grid[y][x].Wall) {
+				report.Violations = append(report.Violations, Violation{Kind: Misaligned, Point: Point{X: x, Y: y}})
+			}
+		}
+	}
+}
+
+// checkRegions walks every group of connected open cells, starting from the entry, reporting
+// an unreachable exit, the groups other than the entry's and the passages that close a loop.
+func (v *MazeValidator) checkRegions(maze *Maze, entry, exit Point, report *ValidationReport) {
+	visited := make([][]bool, maze.Height)
+	parent := make([][]Point, maze.Height)
+
+	for y := range visited {
+		visited[y] = make([]bool, maze.Width)
+		parent[y] = make([]Point, maze.Width)
+	}
+
+	directions := []Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+	open := func(p Point) bool { return maze.Contains(p) && !maze.Grid[p.Y][p.X].Wall }
+
+	// walk returns the number of cells in the region of start and the cells closing a loop
+	walk := func(start Point) (int, []Point) {
+		region := []Point{start}
+		visited[start.Y][start.X] = true
+		parent[start.Y][start.X] = start
+
+		for i := 0; i < len(region); i++ {
+			current := region[i]
+
+			for _, dir := range directions {
+				n := Point{X: current.X + dir.X, Y: current.Y + dir.Y}
+				if open(n) && !visited[n.Y][n.X] {
+					visited[n.Y][n.X] = true
+					parent[n.Y][n.X] = current
+					region = append(region, n)
+				}
+			}
+		}
+
+		// Passages to the right and down are seen once; those outside the search tree close loops
+		var loops []Point
+
+		for _, p := range region {
+			for _, n := range []Point{{X: p.X + 1, Y: p.Y}, {X: p.X, Y: p.Y + 1}} {
+				if open(n) && parent[n.Y][n.X] != p && parent[p.Y][p.X] != n {
+					loops = append(loops, n)
+				}
+			}
+		}
+
+		return len(region), loops
+	}
+
+	_, loops := walk(entry)
+
+	if v.Checks&CheckReachability != 0 && !visited[exit.Y][exit.X] {
+		report.Violations = append(report.Violations, Violation{Kind: UnreachableExit, Point: exit})
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if maze.Grid[y][x].Wall || visited[y][x] {
+				continue
+			}
+
+			cells, regionLoops := walk(Point{X: x, Y: y})
+			loops = append(loops, regionLoops...)
+
+			if v.Checks&CheckReachability != 0 {
+				report.Violations = append(report.Violations, Violation{Kind: IsolatedRegion, Point: Point{X: x, Y: y}, Cells: cells})
+			}
+		}
+	}
+
+	if v.Checks&CheckLoops != 0 {
+		for _, p := range loops {
+			report.Violations = append(report.Violations, Violation{Kind: Loop, Point: p})
+		}
+	}
+}
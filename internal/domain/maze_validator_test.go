@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// mazeFromRows builds a maze from text rows where '#' is a wall.
+func mazeFromRows(rows ...string) *domain.Maze {
+	maze := domain.NewMaze(len(rows[0]), len(rows))
+
+	for y, row := range rows {
+		for x, c := range row {
+			maze.Grid[y][x].Wall = c == '#'
+		}
+	}
+
+	return maze
+}
+
+func TestMazeValidator_Validate_PerfectMaze(t *testing.T) {
+	maze := mazeFromRows(
+		"#.#####",
+		"#.....#",
+		"#.###.#",
+		"#...#.#",
+		"#.#####",
+		"#.....#",
+		"###.###",
+	)
+
+	report := domain.NewMazeValidator(domain.AllChecks).Validate(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 6})
+	if !report.Valid() {
+		t.Fatalf("Expected a valid maze, got %v", report.Err())
+	}
+
+	if report.Err() != nil {
+		t.Errorf("Expected no error, got %v", report.Err())
+	}
+}
+
+func TestMazeValidator_Validate_Violations(t *testing.T) {
+	maze := mazeFromRows(
+		"#.#####",
+		"#.....#",
+		"#.#.#.#",
+		"#.....#",
+		"##..###",
+		"#.#.###",
+		"#.#####",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 1, Y: 6}
+
+	report := domain.NewMazeValidator(domain.AllChecks).Validate(maze, entry, exit)
+
+	expected := map[domain.ViolationKind]int{
+		// The exit column (1, 5)-(1, 6) is cut off from the rest
+		domain.UnreachableExit: 1,
+		domain.IsolatedRegion:  1,
+		// Two loops around the pillars at the top and one through the open pillar (2, 4)
+		domain.Loop: 3,
+		// (2, 4) is an open pillar and (5, 5) a closed cell
+		domain.Misaligned: 2,
+	}
+
+	for kind, count := range expected {
+		if got := report.Count(kind); got != count {
+			t.Errorf("Expected %d violations of kind %v, got %d: %v", count, kind, got, report.Violations)
+		}
+	}
+
+	if report.Has(domain.BorderOpening) {
+		t.Errorf("Expected intact borders, got %v", report.Violations)
+	}
+
+	for _, v := range report.Violations {
+		if v.Kind == domain.IsolatedRegion && (v.Point != domain.Point{X: 1, Y: 5} || v.Cells != 2) {
+			t.Errorf("Expected an isolated region of 2 cells at (1, 5), got %v", v)
+		}
+	}
+
+	if err := report.Err(); !errors.Is(err, domain.ErrInvalidMaze) {
+		t.Errorf("Expected ErrInvalidMaze, got %v", err)
+	}
+}
+
+func TestMazeValidator_Validate_BordersAndChecks(t *testing.T) {
+	maze := mazeFromRows(
+		"#.###",
+		"#...#",
+		"###..",
+	)
+	entry, exit := domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 2}
+
+	report := domain.NewMazeValidator(domain.CheckBorders).Validate(maze, entry, exit)
+	if report.Count(domain.BorderOpening) != 1 || report.Violations[0].Point != (domain.Point{X: 4, Y: 2}) {
+		t.Errorf("Expected a border opening at (4, 2), got %v", report.Violations)
+	}
+
+	// Only the selected checks run
+	report = domain.NewMazeValidator(domain.CheckReachability|domain.CheckLoops).Validate(maze, entry, exit)
+	if !report.Valid() {
+		t.Errorf("Expected a valid maze, got %v", report.Violations)
+	}
+
+	// An entry inside a wall cannot lead anywhere
+	report = domain.NewMazeValidator(domain.AllChecks).Validate(maze, domain.Point{X: 0, Y: 1}, exit)
+	if !report.Has(domain.UnreachableExit) {
+		t.Errorf("Expected an unreachable exit, got %v", report.Violations)
+	}
+}
@@ -10,10 +10,18 @@ type Cell struct {
 	Wall    bool
 }
 
+// Maze is a grid of cells. Keys, doors, teleporters and one-way passages are rare, so they are
+// kept in sparse maps by cell instead of in every cell; the maps are nil until first used.
 type Maze struct {
 	Width  int
 	Height int
 	Grid   [][]Cell
+	// Items holds the keys and doors lying in the cells.
+	Items map[Point]Item
+	// Teleports maps every teleporter to the paired cell it leads to.
+	Teleports map[Point]Point
+	// OneWays maps every one-way passage to the direction it cannot be walked against.
+	OneWays map[Point]Point
 }
 
 func NewMaze(width, height int) *Maze {
@@ -0,0 +1,94 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrInvalidTeleporter is returned when a teleporter is paired with itself.
+	ErrInvalidTeleporter = errors.New("teleporter must lead to another cell")
+	// ErrInvalidDirection is returned when a one-way passage does not point up, right, down or left.
+	ErrInvalidDirection = errors.New("direction must be a single orthogonal step")
+)
+
+// AddTeleporter pairs two passage cells: stepping from either of them to the other takes a single move.
+// A cell that is already a teleporter is re-paired and its previous partner becomes an ordinary cell.
+func (m *Maze) AddTeleporter(a, b Point) error {
+	if err := m.validatePassage("teleporter", a); err != nil {
+		return err
+	}
+
+	if err := m.validatePassage("teleporter", b); err != nil {
+		return err
+	}
+
+	if a == b {
+		return &PointError{Name: "teleporter", Point: a, Err: ErrInvalidTeleporter}
+	}
+
+	for _, p := range []Point{a, b} {
+		if partner, ok := m.Teleports[p]; ok {
+			delete(m.Teleports, partner)
+		}
+	}
+
+	if m.Teleports == nil {
+		m.Teleports = make(map[Point]Point)
+	}
+
+	m.Teleports[a] = b
+	m.Teleports[b] = a
+
+	return nil
+}
+
+// TeleportTarget returns the cell the teleporter in the cell leads to, if there is one.
+func (m *Maze) TeleportTarget(p Point) (Point, bool) {
+	target, ok := m.Teleports[p]
+	return target, ok
+}
+
+// SetOneWay makes the passage cell impossible to walk against the direction given as a unit step,
+// e.g. {X: 1} for a passage that cannot be walked to the left. Steps across it stay allowed.
+func (m *Maze) SetOneWay(p, direction Point) error {
+	if err := m.validatePassage("one-way passage", p); err != nil {
+		return err
+	}
+
+	if abs(direction.X)+abs(direction.Y) != 1 {
+		return &PointError{Name: "one-way passage", Point: p, Err: ErrInvalidDirection}
+	}
+
+	if m.OneWays == nil {
+		m.OneWays = make(map[Point]Point)
+	}
+
+	m.OneWays[p] = direction
+
+	return nil
+}
+
+// OneWay returns the direction of the one-way passage in the cell, zero for two-way cells.
+func (m *Maze) OneWay(p Point) Point {
+	return m.OneWays[p]
+}
+
+// CanStep checks that moving between two adjacent cells, straight or diagonally, does not go
+// against a one-way passage the move starts or ends in.
+func (m *Maze) CanStep(from, to Point) bool {
+	step := Point{X: to.X - from.X, Y: to.Y - from.Y}
+
+	for _, p := range []Point{from, to} {
+		if oneWay := m.OneWays[p]; step.X*oneWay.X+step.Y*oneWay.Y < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,103 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestMaze_AddTeleporter(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+	maze.Grid[2][2].Wall = true
+
+	a, b := domain.Point{X: 1, Y: 1}, domain.Point{X: 3, Y: 3}
+	if err := maze.AddTeleporter(a, b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if target, ok := maze.TeleportTarget(a); !ok || target != b {
+		t.Errorf("Expected teleporter at %v to lead to %v, got %v", a, b, target)
+	}
+
+	if target, ok := maze.TeleportTarget(b); !ok || target != a {
+		t.Errorf("Expected teleporter at %v to lead to %v, got %v", b, a, target)
+	}
+
+	if err := maze.AddTeleporter(a, domain.Point{X: 2, Y: 2}); !errors.Is(err, domain.ErrPointInWall) {
+		t.Errorf("Expected ErrPointInWall, got %v", err)
+	}
+
+	if err := maze.AddTeleporter(a, a); !errors.Is(err, domain.ErrInvalidTeleporter) {
+		t.Errorf("Expected ErrInvalidTeleporter, got %v", err)
+	}
+
+	// Re-pairing a leaves its old partner without a teleporter
+	c := domain.Point{X: 1, Y: 3}
+	if err := maze.AddTeleporter(a, c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if target, ok := maze.TeleportTarget(b); ok {
+		t.Errorf("Expected %v to no longer be a teleporter, got one leading to %v", b, target)
+	}
+
+	if target, ok := maze.TeleportTarget(c); !ok || target != a {
+		t.Errorf("Expected teleporter at %v to lead to %v, got %v", c, a, target)
+	}
+}
+
+func TestCell_Size(t *testing.T) {
+	// Huge mazes keep a cell per grid point, so items and passages must stay out of the cells
+	if size := unsafe.Sizeof(domain.Cell{}); size > 2 {
+		t.Errorf("Expected a cell of at most 2 bytes, got %d", size)
+	}
+}
+
+func TestMaze_SetOneWay(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+	p := domain.Point{X: 2, Y: 2}
+
+	if err := maze.SetOneWay(p, domain.Point{X: 1, Y: 1}); !errors.Is(err, domain.ErrInvalidDirection) {
+		t.Errorf("Expected ErrInvalidDirection, got %v", err)
+	}
+
+	if err := maze.SetOneWay(p, domain.Point{X: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	left, right, up := domain.Point{X: 1, Y: 2}, domain.Point{X: 3, Y: 2}, domain.Point{X: 2, Y: 1}
+
+	// Along the arrow
+	if !maze.CanStep(left, p) || !maze.CanStep(p, right) {
+		t.Error("Expected steps along the one-way passage to be allowed")
+	}
+
+	// Against the arrow
+	if maze.CanStep(right, p) || maze.CanStep(p, left) {
+		t.Error("Expected steps against the one-way passage to be forbidden")
+	}
+
+	// Sideways
+	if !maze.CanStep(up, p) || !maze.CanStep(p, up) {
+		t.Error("Expected sideways steps to be allowed")
+	}
+}
+
+func TestMaze_CanStep_Diagonal(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+	p := domain.Point{X: 2, Y: 2}
+
+	if err := maze.SetOneWay(p, domain.Point{Y: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !maze.CanStep(domain.Point{X: 1, Y: 1}, p) || !maze.CanStep(p, domain.Point{X: 3, Y: 3}) {
+		t.Error("Expected diagonal steps down the one-way passage to be allowed")
+	}
+
+	if maze.CanStep(domain.Point{X: 1, Y: 3}, p) || maze.CanStep(p, domain.Point{X: 3, Y: 1}) {
+		t.Error("Expected diagonal steps up the one-way passage to be forbidden")
+	}
+}
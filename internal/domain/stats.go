@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// SearchStats describes the work done by a solver while looking for a path.
+type SearchStats struct {
+	NodesExpanded  int
+	MaxFrontier    int
+	Visited        []Point
+	Elapsed        time.Duration
+	Allocations    uint64
+	AllocatedBytes uint64
+}
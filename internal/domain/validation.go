@@ -0,0 +1,108 @@
+package domain
+
+// ValidateDimensions checks that the maze is large enough to be generated and its grid matches its size.
+func (m *Maze) ValidateDimensions() error {
+	if m.Width < MinMazeSize || m.Height < MinMazeSize || !m.hasConsistentGrid() {
+		return &DimensionsError{Width: m.Width, Height: m.Height}
+	}
+
+	return nil
+}
+
+// ValidateGenerationPoints checks that the maze can be generated with the entry and exit, which
+// must be border points other than the corners.
+func (m *Maze) ValidateGenerationPoints(entry, exit Point) error {
+	if err := m.ValidateDimensions(); err != nil {
+		return err
+	}
+
+	if err := m.validateOpening("entry", entry); err != nil {
+		return err
+	}
+
+	return m.validateOpening("exit", exit)
+}
+
+// validateOpening checks that the named point lies on the border of the maze and is not a corner.
+func (m *Maze) validateOpening(name string, p Point) error {
+	if !m.Contains(p) {
+		return &PointError{Name: name, Point: p, Err: ErrPointOutOfBounds}
+	}
+
+	onX := p.X == 0 || p.X == m.Width-1
+	onY := p.Y == 0 || p.Y == m.Height-1
+
+	if onX == onY {
+		return &PointError{Name: name, Point: p, Err: ErrInvalidOpening}
+	}
+
+	return nil
+}
+
+// ValidateSolvePoints checks that the entry and exit lie within the maze and are passages.
+func (m *Maze) ValidateSolvePoints(entry, exit Point) error {
+	if !m.hasConsistentGrid() {
+		return &DimensionsError{Width: m.Width, Height: m.Height}
+	}
+
+	if err := m.validatePassage("entry", entry); err != nil {
+		return err
+	}
+
+	return m.validatePassage("exit", exit)
+}
+
+// Contains checks whether the point lies within the maze grid.
+func (m *Maze) Contains(p Point) bool {
+	return p.X >= 0 && p.X < m.Width && p.Y >= 0 && p.Y < m.Height
+}
+
+// validatePassage checks that the named point lies within the maze and is not a wall.
+func (m *Maze) validatePassage(name string, p Point) error {
+	if !m.Contains(p) {
+		return &PointError{Name: name, Point: p, Err: ErrPointOutOfBounds}
+	}
+
+	if m.Grid[p.Y][p.X].Wall {
+		return &PointError{Name: name, Point: p, Err: ErrPointInWall}
+	}
+
+	return nil
+}
+
+// hasConsistentGrid checks that the grid has Height rows of Width cells.
+func (m *Maze) hasConsistentGrid() bool {
+	if m.Width < 0 || m.Height < 0 || len(m.Grid) != m.Height {
+		return false
+	}
+
+	for _, row := range m.Grid {
+		if len(row) != m.Width {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateItems checks that every key and door has a color below MaxItemColors.
+func (m *Maze) ValidateItems() error {
+	for p, item := range m.Items {
+		if item.Kind != NoItem && (item.Color < 0 || item.Color >= MaxItemColors) {
+			return &PointError{Name: "item", Point: p, Err: ErrInvalidItemColor}
+		}
+	}
+
+	return nil
+}
+
+// ValidateWaypoints checks that every waypoint lies within the maze and is a passage.
+func (m *Maze) ValidateWaypoints(waypoints []Point) error {
+	for _, p := range waypoints {
+		if err := m.validatePassage("waypoint", p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+func TestMaze_ValidateDimensions(t *testing.T) {
+	if err := domain.NewMaze(3, 5).ValidateDimensions(); err != nil {
+		t.Errorf("Expected a 3x5 maze to be valid, got %v", err)
+	}
+
+	err := domain.NewMaze(1, 5).ValidateDimensions()
+	if !errors.Is(err, domain.ErrInvalidDimensions) {
+		t.Fatalf("Expected ErrInvalidDimensions, got %v", err)
+	}
+
+	var dimensionsErr *domain.DimensionsError
+	if !errors.As(err, &dimensionsErr) || dimensionsErr.Width != 1 || dimensionsErr.Height != 5 {
+		t.Errorf("Expected DimensionsError for 1x5, got %v", err)
+	}
+
+	// A grid that does not match the declared size
+	maze := domain.NewMaze(5, 5)
+	maze.Grid = maze.Grid[:3]
+
+	if err := maze.ValidateDimensions(); !errors.Is(err, domain.ErrInvalidDimensions) {
+		t.Errorf("Expected ErrInvalidDimensions for an inconsistent grid, got %v", err)
+	}
+}
+
+func TestMaze_ValidateSolvePoints(t *testing.T) {
+	maze := domain.NewMaze(5, 5)
+	maze.Grid[4][3].Wall = true
+
+	if err := maze.ValidateSolvePoints(domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 3}); err != nil {
+		t.Errorf("Expected valid points, got %v", err)
+	}
+
+	err := maze.ValidateSolvePoints(domain.Point{X: -1, Y: 0}, domain.Point{X: 3, Y: 3})
+	if !errors.Is(err, domain.ErrPointOutOfBounds) {
+		t.Errorf("Expected ErrPointOutOfBounds, got %v", err)
+	}
+
+	err = maze.ValidateSolvePoints(domain.Point{X: 1, Y: 0}, domain.Point{X: 3, Y: 4})
+	if !errors.Is(err, domain.ErrPointInWall) {
+		t.Fatalf("Expected ErrPointInWall, got %v", err)
+	}
+
+	var pointErr *domain.PointError
+	if !errors.As(err, &pointErr) || pointErr.Name != "exit" {
+		t.Errorf("Expected PointError for the exit, got %v", err)
+	}
+}
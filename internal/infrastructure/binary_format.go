@@ -0,0 +1,321 @@
+package infrastructure
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// Binary maze format (little endian):
+//
+//	magic    [4]byte "MAZB"
+//	version  uint8
+//	flags    uint8   bit 0 - the wall data is gzip-compressed
+//	reserved uint16
+//	width    uint32
+//	height   uint32
+//	seed     int64
+//	entry    uint32 x, uint32 y
+//	exit     uint32 x, uint32 y
+//
+// The header is followed by the walls, one row after another, packed 8 cells per byte
+// with the lowest bit holding the leftmost cell. Each row is padded to a whole byte.
+const (
+	BinaryFormatVersion = 1
+	// MaxBinaryMazeSize is the largest width and height accepted in a binary stream, so a corrupt
+	// header cannot make the reader allocate an unbounded grid.
+	MaxBinaryMazeSize = 1 << 16
+
+	binaryFlagGzip = 1 << 0
+)
+
+var binaryMagic = [4]byte{'M', 'A', 'Z', 'B'}
+
+var (
+	// ErrInvalidMagic is returned when the stream does not start with the maze format signature.
+	ErrInvalidMagic = errors.New("not a binary maze stream")
+	// ErrUnsupportedVersion is returned for streams with an unknown format version.
+	ErrUnsupportedVersion = errors.New("unsupported binary maze format version")
+	// ErrRowLength is returned when a row does not match the maze width.
+	ErrRowLength = errors.New("row length does not match maze width")
+	// ErrTooManyRows is returned when writing or reading past the last row.
+	ErrTooManyRows = errors.New("all maze rows have already been processed")
+	// ErrIncompleteMaze is returned when the writer is closed before all rows were written.
+	ErrIncompleteMaze = errors.New("maze stream is incomplete")
+)
+
+// MazeHeader describes the maze stored in a binary stream.
+type MazeHeader struct {
+	Width      int
+	Height     int
+	Seed       int64
+	Entry      domain.Point
+	Exit       domain.Point
+	Compressed bool
+}
+
+// rawMazeHeader is the on-disk layout of MazeHeader.
+type rawMazeHeader struct {
+	Magic    [4]byte
+	Version  uint8
+	Flags    uint8
+	Reserved uint16
+	Width    uint32
+	Height   uint32
+	Seed     int64
+	EntryX   uint32
+	EntryY   uint32
+	ExitX    uint32
+	ExitY    uint32
+}
+
+// BinaryWriter writes a maze row by row so large mazes never have to be held in memory twice.
+type BinaryWriter struct {
+	header MazeHeader
+	out    *bufio.Writer
+	gz     *gzip.Writer
+	body   io.Writer
+	row    []byte
+	rows   int
+}
+
+// NewBinaryWriter writes the header and prepares the writer for the rows.
+func NewBinaryWriter(w io.Writer, header MazeHeader) (*BinaryWriter, error) {
+	if err := validateBinaryHeader(header); err != nil {
+		return nil, err
+	}
+
+	raw := rawMazeHeader{
+		Magic:   binaryMagic,
+		Version: BinaryFormatVersion,
+		Width:   uint32(header.Width),
+		Height:  uint32(header.Height),
+		Seed:    header.Seed,
+		EntryX:  uint32(header.Entry.X),
+		EntryY:  uint32(header.Entry.Y),
+		ExitX:   uint32(header.Exit.X),
+		ExitY:   uint32(header.Exit.Y),
+	}
+
+	if header.Compressed {
+		raw.Flags |= binaryFlagGzip
+	}
+
+	bw := &BinaryWriter{
+		header: header,
+		out:    bufio.NewWriter(w),
+		row:    make([]byte, (header.Width+7)/8),
+	}
+
+	if err := binary.Write(bw.out, binary.LittleEndian, raw); err != nil {
+		return nil, err
+	}
+
+	bw.body = bw.out
+
+	if header.Compressed {
+		bw.gz = gzip.NewWriter(bw.out)
+		bw.body = bw.gz
+	}
+
+	return bw, nil
+}
+
+// WriteRow packs and writes the next row of the maze.
+func (w *BinaryWriter) WriteRow(cells []domain.Cell) error {
+	if w.rows >= w.header.Height {
+		return ErrTooManyRows
+	}
+
+	if len(cells) != w.header.Width {
+		return fmt.Errorf("%w: got %d cells, want %d", ErrRowLength, len(cells), w.header.Width)
+	}
+
+	for i := range w.row {
+		w.row[i] = 0
+	}
+
+	for x, cell := range cells {
+		if cell.Wall {
+			w.row[x/8] |= 1 << (x % 8)
+		}
+	}
+
+	if _, err := w.body.Write(w.row); err != nil {
+		return err
+	}
+
+	w.rows++
+
+	return nil
+}
+
+// WriteMaze writes all remaining rows of the maze.
+func (w *BinaryWriter) WriteMaze(maze *domain.Maze) error {
+	for y := w.rows; y < maze.Height; y++ {
+		if err := w.WriteRow(maze.Grid[y]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close flushes the stream. It does not close the underlying writer.
+func (w *BinaryWriter) Close() error {
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.out.Flush(); err != nil {
+		return err
+	}
+
+	if w.rows != w.header.Height {
+		return fmt.Errorf("%w: wrote %d of %d rows", ErrIncompleteMaze, w.rows, w.header.Height)
+	}
+
+	return nil
+}
+
+// BinaryReader reads a maze written by BinaryWriter row by row.
+type BinaryReader struct {
+	header MazeHeader
+	body   io.Reader
+	row    []byte
+	rows   int
+}
+
+// NewBinaryReader reads and validates the header of the stream.
+func NewBinaryReader(r io.Reader) (*BinaryReader, error) {
+	in := bufio.NewReader(r)
+
+	var raw rawMazeHeader
+	if err := binary.Read(in, binary.LittleEndian, &raw); err != nil {
+		return nil, err
+	}
+
+	if raw.Magic != binaryMagic {
+		return nil, ErrInvalidMagic
+	}
+
+	if raw.Version == 0 || raw.Version > BinaryFormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, raw.Version)
+	}
+
+	br := &BinaryReader{
+		header: MazeHeader{
+			Width:      int(raw.Width),
+			Height:     int(raw.Height),
+			Seed:       raw.Seed,
+			Entry:      domain.Point{X: int(raw.EntryX), Y: int(raw.EntryY)},
+			Exit:       domain.Point{X: int(raw.ExitX), Y: int(raw.ExitY)},
+			Compressed: raw.Flags&binaryFlagGzip != 0,
+		},
+		body: in,
+	}
+
+	if err := validateBinaryHeader(br.header); err != nil {
+		return nil, err
+	}
+
+	br.row = make([]byte, (raw.Width+7)/8)
+
+	if br.header.Compressed {
+		gz, err := gzip.NewReader(in)
+		if err != nil {
+			return nil, err
+		}
+
+		br.body = gz
+	}
+
+	return br, nil
+}
+
+// Header returns the header of the stream.
+func (r *BinaryReader) Header() MazeHeader {
+	return r.header
+}
+
+// ReadRow reads the next row into cells, updating only the Wall flags.
+func (r *BinaryReader) ReadRow(cells []domain.Cell) error {
+	if r.rows >= r.header.Height {
+		return ErrTooManyRows
+	}
+
+	if len(cells) != r.header.Width {
+		return fmt.Errorf("%w: got %d cells, want %d", ErrRowLength, len(cells), r.header.Width)
+	}
+
+	if _, err := io.ReadFull(r.body, r.row); err != nil {
+		return err
+	}
+
+	for x := range cells {
+		cells[x].Wall = r.row[x/8]&(1<<(x%8)) != 0
+	}
+
+	r.rows++
+
+	// Reading a compressed stream to its end makes gzip verify the checksum of the rows
+	if r.rows == r.header.Height && r.header.Compressed {
+		if _, err := io.Copy(io.Discard, r.body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadMaze reads all remaining rows into a new maze.
+func (r *BinaryReader) ReadMaze() (*domain.Maze, error) {
+	maze := domain.NewMaze(r.header.Width, r.header.Height)
+
+	for y := r.rows; y < r.header.Height; y++ {
+		if err := r.ReadRow(maze.Grid[y]); err != nil {
+			return nil, err
+		}
+	}
+
+	return maze, nil
+}
+
+// validateBinaryHeader checks the size of the maze and that the entry and exit lie within it.
+func validateBinaryHeader(header MazeHeader) error {
+	if err := validateBinaryDimensions(header.Width, header.Height); err != nil {
+		return err
+	}
+
+	bounds := &domain.Maze{Width: header.Width, Height: header.Height}
+
+	if !bounds.Contains(header.Entry) {
+		return &domain.PointError{Name: "entry", Point: header.Entry, Err: domain.ErrPointOutOfBounds}
+	}
+
+	if !bounds.Contains(header.Exit) {
+		return &domain.PointError{Name: "exit", Point: header.Exit, Err: domain.ErrPointOutOfBounds}
+	}
+
+	return nil
+}
+
+// validateBinaryDimensions checks that a maze of the given size can be stored in a binary stream.
+func validateBinaryDimensions(width, height int) error {
+	if width < domain.MinMazeSize || height < domain.MinMazeSize {
+		return &domain.DimensionsError{Width: width, Height: height}
+	}
+
+	if width > MaxBinaryMazeSize || height > MaxBinaryMazeSize {
+		return fmt.Errorf("%w: %dx%d (maximum %dx%d)", domain.ErrInvalidDimensions, width, height, MaxBinaryMazeSize, MaxBinaryMazeSize)
+	}
+
+	return nil
+}
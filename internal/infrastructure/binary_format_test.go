@@ -0,0 +1,173 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+func TestBinaryFormat_RoundTrip(t *testing.T) {
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 17, Y: 20}
+
+	maze := domain.NewMaze(19, 21)
+	if err := (&application.KruskalGenerator{}).Generate(maze, entry, exit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, compressed := range []bool{false, true} {
+		header := infrastructure.MazeHeader{
+			Width: maze.Width, Height: maze.Height, Seed: 42, Entry: entry, Exit: exit, Compressed: compressed,
+		}
+
+		var buf bytes.Buffer
+
+		writer, err := infrastructure.NewBinaryWriter(&buf, header)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := writer.WriteMaze(maze); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		reader, err := infrastructure.NewBinaryReader(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if reader.Header() != header {
+			t.Errorf("Expected header %+v, got %+v", header, reader.Header())
+		}
+
+		decoded, err := reader.ReadMaze()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for y := 0; y < maze.Height; y++ {
+			for x := 0; x < maze.Width; x++ {
+				if decoded.Grid[y][x].Wall != maze.Grid[y][x].Wall {
+					t.Fatalf("Cell (%d,%d) differs after round trip (compressed=%v)", x, y, compressed)
+				}
+			}
+		}
+	}
+}
+
+func TestBinaryFormat_Errors(t *testing.T) {
+	if _, err := infrastructure.NewBinaryReader(bytes.NewReader(make([]byte, 40))); !errors.Is(err, infrastructure.ErrInvalidMagic) {
+		t.Errorf("Expected ErrInvalidMagic, got %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	writer, err := infrastructure.NewBinaryWriter(&buf, infrastructure.MazeHeader{Width: 3, Height: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writer.WriteRow(make([]domain.Cell, 2)); !errors.Is(err, infrastructure.ErrRowLength) {
+		t.Errorf("Expected ErrRowLength, got %v", err)
+	}
+
+	if err := writer.WriteRow(make([]domain.Cell, 3)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writer.Close(); !errors.Is(err, infrastructure.ErrIncompleteMaze) {
+		t.Errorf("Expected ErrIncompleteMaze, got %v", err)
+	}
+}
+
+func TestBinaryFormat_InvalidHeader(t *testing.T) {
+	for _, size := range []domain.Point{{X: 2, Y: 5}, {X: 5, Y: 0}, {X: infrastructure.MaxBinaryMazeSize + 1, Y: 5}} {
+		_, err := infrastructure.NewBinaryWriter(&bytes.Buffer{}, infrastructure.MazeHeader{Width: size.X, Height: size.Y})
+		if !errors.Is(err, domain.ErrInvalidDimensions) {
+			t.Errorf("%dx%d: expected ErrInvalidDimensions from the writer, got %v", size.X, size.Y, err)
+		}
+	}
+
+	for _, p := range []domain.Point{{X: -1, Y: 0}, {X: 2, Y: 5}} {
+		_, err := infrastructure.NewBinaryWriter(&bytes.Buffer{}, infrastructure.MazeHeader{Width: 5, Height: 5, Exit: p})
+		if !errors.Is(err, domain.ErrPointOutOfBounds) {
+			t.Errorf("Exit %v: expected ErrPointOutOfBounds from the writer, got %v", p, err)
+		}
+	}
+
+	var valid bytes.Buffer
+
+	writer, err := infrastructure.NewBinaryWriter(&valid, infrastructure.MazeHeader{Width: 5, Height: 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writer.WriteMaze(domain.NewMaze(5, 5)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Header offsets: version at 4, width at 8, height at 12, entry x at 24
+	tests := map[string]struct {
+		corrupt  func(header []byte)
+		expected error
+	}{
+		"version 0":     {func(h []byte) { h[4] = 0 }, infrastructure.ErrUnsupportedVersion},
+		"next version":  {func(h []byte) { h[4] = infrastructure.BinaryFormatVersion + 1 }, infrastructure.ErrUnsupportedVersion},
+		"zero width":    {func(h []byte) { binary.LittleEndian.PutUint32(h[8:], 0) }, domain.ErrInvalidDimensions},
+		"huge height":   {func(h []byte) { binary.LittleEndian.PutUint32(h[12:], 1<<31) }, domain.ErrInvalidDimensions},
+		"entry outside": {func(h []byte) { binary.LittleEndian.PutUint32(h[24:], 5) }, domain.ErrPointOutOfBounds},
+	}
+
+	for name, tt := range tests {
+		data := bytes.Clone(valid.Bytes())
+		tt.corrupt(data)
+
+		if _, err := infrastructure.NewBinaryReader(bytes.NewReader(data)); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tt.expected, err)
+		}
+	}
+}
+
+func TestBinaryFormat_Checksum(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := infrastructure.NewBinaryWriter(&buf, infrastructure.MazeHeader{Width: 5, Height: 5, Compressed: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writer.WriteMaze(domain.NewMaze(5, 5)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The gzip trailer ends with the CRC-32 and the size of the data
+	data := buf.Bytes()
+	data[len(data)-8] ^= 0xFF
+
+	reader, err := infrastructure.NewBinaryReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := reader.ReadMaze(); !errors.Is(err, gzip.ErrChecksum) {
+		t.Errorf("Expected gzip.ErrChecksum, got %v", err)
+	}
+}
@@ -5,21 +5,30 @@ import (
 
 	"github.com/fatih/color"
 
+	"github.com/abakunov/mazes/internal/analysis"
+	"github.com/abakunov/mazes/internal/application"
 	"github.com/abakunov/mazes/internal/domain"
 )
 
 type ConsoleRenderer struct{}
 
+// oneWayArrows maps the direction of a one-way passage to the arrow it is drawn with.
+var oneWayArrows = map[domain.Point]string{
+	{X: 0, Y: -1}: "↑",
+	{X: 1, Y: 0}:  "→",
+	{X: 0, Y: 1}:  "↓",
+	{X: -1, Y: 0}: "←",
+}
+
 func (r *ConsoleRenderer) RenderMaze(maze *domain.Maze) {
 	wallColor := color.New(color.FgRed).SprintFunc()
-	pathColor := color.New(color.FgWhite).SprintFunc()
 
 	for y := 0; y < maze.Height; y++ {
 		for x := 0; x < maze.Width; x++ {
 			if maze.Grid[y][x].Wall {
 				fmt.Print(wallColor("██"))
 			} else {
-				fmt.Print(pathColor("  "))
+				fmt.Print(r.passage(maze, domain.Point{X: x, Y: y}, color.FgWhite))
 			}
 		}
 
@@ -29,8 +38,6 @@ func (r *ConsoleRenderer) RenderMaze(maze *domain.Maze) {
 
 func (r *ConsoleRenderer) RenderMazeWithPath(maze *domain.Maze, path []domain.Point) {
 	wallColor := color.New(color.FgRed).SprintFunc()
-	pathColor := color.New(color.FgWhite).SprintFunc()
-	solutionColor := color.New(color.BgGreen).SprintFunc()
 
 	pathSet := make(map[domain.Point]bool)
 	for _, p := range path {
@@ -45,12 +52,139 @@ func (r *ConsoleRenderer) RenderMazeWithPath(maze *domain.Maze, path []domain.Po
 			case maze.Grid[y][x].Wall:
 				fmt.Print(wallColor("██"))
 			case pathSet[p]:
-				fmt.Print(solutionColor("  "))
+				fmt.Print(r.passage(maze, domain.Point{X: x, Y: y}, color.BgGreen))
+			default:
+				fmt.Print(r.passage(maze, domain.Point{X: x, Y: y}, color.FgWhite))
+			}
+		}
+
+		fmt.Println()
+	}
+}
+
+// pathColors are the backgrounds RenderMazeWithPaths cycles through for successive paths.
+var pathColors = []color.Attribute{color.BgGreen, color.BgBlue, color.BgMagenta, color.BgCyan, color.BgYellow}
+
+// RenderMazeWithPaths draws several paths over the maze, each in its own color, followed by
+// a legend. A cell shared by several paths takes the color of the first of them.
+func (r *ConsoleRenderer) RenderMazeWithPaths(maze *domain.Maze, paths [][]domain.Point) {
+	wallColor := color.New(color.FgRed).SprintFunc()
+
+	pathIndex := make(map[domain.Point]int)
+
+	for i := len(paths) - 1; i >= 0; i-- {
+		for _, p := range paths[i] {
+			pathIndex[p] = i
+		}
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			i, onPath := pathIndex[domain.Point{X: x, Y: y}]
+
+			switch {
+			case maze.Grid[y][x].Wall:
+				fmt.Print(wallColor("██"))
+			case onPath:
+				fmt.Print(r.passage(maze, domain.Point{X: x, Y: y}, pathColors[i%len(pathColors)]))
+			default:
+				fmt.Print(r.passage(maze, domain.Point{X: x, Y: y}, color.FgWhite))
+			}
+		}
+
+		fmt.Println()
+	}
+
+	for i, path := range paths {
+		fmt.Printf("%s Path %d: %d cells\n", color.New(pathColors[i%len(pathColors)]).Sprint("  "), i+1, len(path))
+	}
+}
+
+// heatmapColors are the backgrounds of RenderHeatmap from the cells closest to the target to the farthest ones.
+var heatmapColors = []color.Attribute{color.BgBlue, color.BgCyan, color.BgGreen, color.BgYellow, color.BgRed, color.BgMagenta}
+
+// RenderHeatmap draws the distance of every cell to the target of the flow field, coloring
+// cells from near to far and printing the last two digits of the distance.
+func (r *ConsoleRenderer) RenderHeatmap(maze *domain.Maze, field *application.FlowField) {
+	wallColor := color.New(color.FgRed).SprintFunc()
+	longest := max(field.MaxDistance(), 1)
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			d := field.Distance(domain.Point{X: x, Y: y})
+
+			switch {
+			case maze.Grid[y][x].Wall:
+				fmt.Print(wallColor("██"))
+			case d == application.Unreachable:
+				fmt.Print("  ")
 			default:
-				fmt.Print(pathColor("  "))
+				bucket := d * (len(heatmapColors) - 1) / longest
+				fmt.Print(color.New(heatmapColors[bucket], color.FgBlack).Sprintf("%2d", d%100))
 			}
 		}
 
 		fmt.Println()
 	}
 }
+
+// passage draws a passage cell with the given attributes, marking teleporters and one-way passages.
+func (r *ConsoleRenderer) passage(maze *domain.Maze, p domain.Point, attributes ...color.Attribute) string {
+	symbol := "  "
+
+	_, teleporter := maze.TeleportTarget(p)
+	oneWay := maze.OneWay(p)
+
+	switch {
+	case teleporter:
+		symbol = "<>"
+		attributes = append(attributes, color.FgMagenta, color.Bold)
+	case oneWay != (domain.Point{}):
+		symbol = oneWayArrows[oneWay] + " "
+		attributes = append(attributes, color.FgYellow, color.Bold)
+	}
+
+	return color.New(attributes...).Sprint(symbol)
+}
+
+// SolverStats is a row of the solver comparison table.
+type SolverStats struct {
+	Name       string
+	PathLength int
+	Stats      domain.SearchStats
+}
+
+// RenderSearchStats prints the work done by each solver side by side.
+func (r *ConsoleRenderer) RenderSearchStats(rows []SolverStats) {
+	headerColor := color.New(color.Bold).SprintFunc()
+
+	fmt.Println(headerColor(fmt.Sprintf("%-22s %8s %10s %10s %10s %12s %8s %10s",
+		"Solver", "Path", "Expanded", "Frontier", "Visited", "Time", "Allocs", "Bytes")))
+
+	for _, row := range rows {
+		fmt.Printf("%-22s %8d %10d %10d %10d %12s %8d %10d\n",
+			row.Name, row.PathLength, row.Stats.NodesExpanded, row.Stats.MaxFrontier, len(row.Stats.Visited),
+			row.Stats.Elapsed, row.Stats.Allocations, row.Stats.AllocatedBytes)
+	}
+}
+
+// MetricsRow is a row of the maze metrics table.
+type MetricsRow struct {
+	Name    string
+	Metrics analysis.Metrics
+}
+
+// RenderMetrics prints the difficulty and texture metrics of one or more mazes side by side.
+func (r *ConsoleRenderer) RenderMetrics(rows []MetricsRow) {
+	headerColor := color.New(color.Bold).SprintFunc()
+
+	fmt.Println(headerColor(fmt.Sprintf("%-12s %8s %9s %10s %9s %8s %8s %9s %7s %7s %9s %10s",
+		"Maze", "Cells", "DeadEnds", "Junctions", "Branching", "Path", "Ratio", "Decision", "Turns", "River", "Corridor", "Difficulty")))
+
+	for _, row := range rows {
+		m := row.Metrics
+		fmt.Printf("%-12s %8d %9d %10d %9.2f %8d %8.3f %9d %7d %7.2f %9d %10.1f\n",
+			row.Name, m.Passages, m.DeadEnds, m.Junctions, m.BranchingFactor, m.SolutionLength, m.SolutionRatio,
+			m.Decisions, m.Turns, m.River, m.LongestCorridor, m.Difficulty)
+	}
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// DOTExporter writes the maze graph in the Graphviz DOT format.
+type DOTExporter struct{}
+
+// NewDOTExporter initializes the DOTExporter.
+func NewDOTExporter() *DOTExporter {
+	return &DOTExporter{}
+}
+
+// Export writes the graph as an undirected DOT graph. Nodes and corridors lying on the path are highlighted.
+func (e *DOTExporter) Export(w io.Writer, graph *application.MazeGraph, path []domain.Point) error {
+	pathSet := make(map[domain.Point]bool)
+	for _, p := range path {
+		pathSet[p] = true
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "graph maze {")
+	fmt.Fprintln(bw, "  node [fontsize=10];")
+
+	for _, node := range graph.Nodes {
+		shape := "circle"
+		if node.Kind != application.Junction {
+			shape = "box"
+		}
+
+		attrs := fmt.Sprintf("label=\"%d,%d\", shape=%s", node.Point.X, node.Point.Y, shape)
+		if pathSet[node.Point] {
+			attrs += ", color=red, style=bold"
+		}
+
+		fmt.Fprintf(bw, "  %s [%s];\n", dotNodeID(node.Point), attrs)
+	}
+
+	for _, edge := range graph.Edges {
+		attrs := fmt.Sprintf("label=\"%d\", weight=%d", edge.Length, edge.Length)
+		if e.isOnPath(edge, pathSet) {
+			attrs += ", color=red, penwidth=3"
+		}
+
+		fmt.Fprintf(bw, "  %s -- %s [%s];\n", dotNodeID(edge.From), dotNodeID(edge.To), attrs)
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// isOnPath checks whether the whole corridor including its ends belongs to the path.
+func (e *DOTExporter) isOnPath(edge application.GraphEdge, pathSet map[domain.Point]bool) bool {
+	if len(pathSet) == 0 || !pathSet[edge.From] || !pathSet[edge.To] {
+		return false
+	}
+
+	for _, c := range edge.Cells {
+		if !pathSet[c] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dotNodeID returns the DOT identifier of the node located at the point.
+func dotNodeID(p domain.Point) string {
+	return fmt.Sprintf("n%d_%d", p.X, p.Y)
+}
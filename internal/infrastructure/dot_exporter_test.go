@@ -0,0 +1,53 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+func TestDOTExporter_Export_HighlightsPath(t *testing.T) {
+	maze := domain.NewMaze(5, 3)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 5; x++ {
+			maze.Grid[y][x].Wall = true
+		}
+	}
+
+	// A single corridor with a side branch: (0,1) - (1,1) - (2,1) - (3,1) - (4,1), branch at (2,2)
+	for x := 0; x < 5; x++ {
+		maze.Grid[1][x].Wall = false
+	}
+
+	maze.Grid[2][2].Wall = false
+
+	graph := application.NewGraphExtractor().Extract(maze)
+	path := []domain.Point{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}}
+
+	var buf bytes.Buffer
+	if err := infrastructure.NewDOTExporter().Export(&buf, graph, path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+
+	if !strings.HasPrefix(output, "graph maze {") {
+		t.Error("Expected output to start with an undirected graph declaration")
+	}
+
+	if strings.Count(output, " -- ") != 3 {
+		t.Errorf("Expected 3 edges, got %d", strings.Count(output, " -- "))
+	}
+
+	if strings.Count(output, "penwidth=3") != 2 {
+		t.Errorf("Expected 2 highlighted edges, got %d", strings.Count(output, "penwidth=3"))
+	}
+
+	if !strings.Contains(output, `n2_1 [label="2,1", shape=circle, color=red, style=bold];`) {
+		t.Error("Expected the junction on the path to be highlighted")
+	}
+}
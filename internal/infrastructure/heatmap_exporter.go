@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// ErrInvalidCellSize is returned when the cell size of an image is not positive.
+var ErrInvalidCellSize = errors.New("cell size must be positive")
+
+var (
+	heatmapWallColor        = color.RGBA{R: 40, G: 40, B: 40, A: 255}
+	heatmapUnreachableColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// HeatmapExporter draws the distances of a flow field as a PNG image, from blue next to
+// the target through green and yellow to red for the farthest cells.
+type HeatmapExporter struct {
+	// CellSize is the side of a maze cell in pixels.
+	CellSize int
+}
+
+// NewHeatmapExporter initializes the HeatmapExporter with 8-pixel cells.
+func NewHeatmapExporter() *HeatmapExporter {
+	return &HeatmapExporter{CellSize: 8}
+}
+
+// Export writes the heatmap of the flow field over the maze as a PNG image.
+func (e *HeatmapExporter) Export(w io.Writer, maze *domain.Maze, field *application.FlowField) error {
+	if e.CellSize <= 0 {
+		return ErrInvalidCellSize
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, maze.Width*e.CellSize, maze.Height*e.CellSize))
+	longest := max(field.MaxDistance(), 1)
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			c := heatmapUnreachableColor
+
+			if d := field.Distance(domain.Point{X: x, Y: y}); maze.Grid[y][x].Wall {
+				c = heatmapWallColor
+			} else if d != application.Unreachable {
+				c = e.gradient(float64(d) / float64(longest))
+			}
+
+			for py := y * e.CellSize; py < (y+1)*e.CellSize; py++ {
+				for px := x * e.CellSize; px < (x+1)*e.CellSize; px++ {
+					img.SetRGBA(px, py, c)
+				}
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// gradient maps 0 to blue, 1/3 to green, 2/3 to yellow and 1 to red.
+func (e *HeatmapExporter) gradient(t float64) color.RGBA {
+	stops := []color.RGBA{
+		{R: 0, G: 0, B: 255, A: 255},
+		{R: 0, G: 200, B: 0, A: 255},
+		{R: 255, G: 220, B: 0, A: 255},
+		{R: 220, G: 0, B: 0, A: 255},
+	}
+
+	scaled := t * float64(len(stops)-1)
+	i := min(int(scaled), len(stops)-2)
+	f := scaled - float64(i)
+
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + f*(float64(b)-float64(a)) + 0.5)
+	}
+
+	return color.RGBA{
+		R: lerp(stops[i].R, stops[i+1].R),
+		G: lerp(stops[i].G, stops[i+1].G),
+		B: lerp(stops[i].B, stops[i+1].B),
+		A: 255,
+	}
+}
@@ -0,0 +1,72 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+func TestHeatmapExporter_Export(t *testing.T) {
+	maze := domain.NewMaze(5, 1)
+	maze.Grid[0][4].Wall = true
+
+	field, err := application.NewFlowFieldBuilder().Build(maze, domain.Point{X: 0, Y: 0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	exporter := &infrastructure.HeatmapExporter{CellSize: 4}
+	if err := exporter.Export(&buf, maze, field); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if size := img.Bounds().Size(); size.X != 20 || size.Y != 4 {
+		t.Fatalf("Expected a 20x4 image, got %v", size)
+	}
+
+	colorAt := func(cellX int) color.RGBA {
+		return color.RGBAModel.Convert(img.At(cellX*4+2, 2)).(color.RGBA)
+	}
+
+	// The target is blue and the farthest cell is red
+	if c := colorAt(0); c.B != 255 || c.R != 0 {
+		t.Errorf("Expected the target to be blue, got %v", c)
+	}
+
+	if c := colorAt(3); c.R < 200 || c.B != 0 {
+		t.Errorf("Expected the farthest cell to be red, got %v", c)
+	}
+
+	if c := colorAt(4); c.R != c.G || c.G != c.B || c.R > 100 {
+		t.Errorf("Expected the wall to be dark gray, got %v", c)
+	}
+}
+
+func TestHeatmapExporter_Export_InvalidCellSize(t *testing.T) {
+	maze := domain.NewMaze(3, 3)
+
+	field, err := application.NewFlowFieldBuilder().Build(maze, domain.Point{X: 1, Y: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = (&infrastructure.HeatmapExporter{}).Export(&buf, maze, field)
+	if !errors.Is(err, infrastructure.ErrInvalidCellSize) {
+		t.Errorf("Expected ErrInvalidCellSize, got %v", err)
+	}
+}
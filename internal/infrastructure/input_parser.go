@@ -23,20 +23,43 @@ func GetHeight() int {
 }
 
 func GetAlgorithmChoice() int {
-	return getIntInput("Выберите алгоритм генерации лабиринта (1 - DFS, 2 - Kruskal): ", 1, "Ошибка: выберите 1 (DFS) или 2 (Kruskal).", 2)
+	return getIntInput("Выберите алгоритм генерации лабиринта (1 - DFS, 2 - Kruskal, 3 - параллельный): ", 1,
+		"Ошибка: выберите 1 (DFS), 2 (Kruskal) или 3 (параллельный).", 3)
+}
+
+// GetWorkerCount asks for the number of goroutines of the parallel generator, 0 meaning one per CPU.
+func GetWorkerCount() int {
+	return getIntInput("Количество потоков генерации (0 - по числу процессоров): ", 0, "Ошибка: введите неотрицательное число.")
 }
 
 func GetEntryExitChoice() int {
-	return getIntInput("Ввести точки входа и выхода вручную или случайным образом (1 - вручную, 2 - случайным образом): ", 1,
-		"Ошибка: выберите 1 (вручную) или 2 (случайным образом).", 2)
+	return getIntInput(
+		"Как выбрать точки входа и выхода (1 - вручную, 2 - случайным образом, 3 - самый длинный путь, 4 - заданная сложность): ", 1,
+		"Ошибка: выберите 1 (вручную), 2 (случайным образом), 3 (самый длинный путь) или 4 (заданная сложность).", 4)
+}
+
+// GetTargetDifficulty asks for the difficulty score the entry and exit should be placed for.
+func GetTargetDifficulty() int {
+	return getIntInput("Желаемая сложность (от 0 до 100): ", 0, "Ошибка: введите число от 0 до 100.", 100)
 }
 
 func GetPathSolverChoice() int {
 	return getIntInput("Выберите алгоритм поиска пути (1 - BFS, 2 - A*): ", 1, "Ошибка: выберите 1 (BFS) или 2 (A*).", 2)
 }
 
-// GetEntryExitPoints gets the entry and exit points either manually or randomly.
+// GetComparisonChoice asks whether the generators should be compared on mazes of the same size.
+func GetComparisonChoice() int {
+	return getIntInput("Сравнить генераторы на лабиринтах того же размера (1 - да, 2 - нет): ", 1,
+		"Ошибка: выберите 1 (да) или 2 (нет).", 2)
+}
+
+// GetEntryExitPoints gets the entry and exit points either manually or randomly. When the points
+// are placed after generation, the opposite corners of the cell lattice are used to generate the maze.
 func GetEntryExitPoints(choice, width, height int) (entryPoint, exitPoint domain.Point) {
+	if choice == 3 || choice == 4 {
+		return domain.Point{X: 1, Y: 0}, domain.Point{X: width - 2, Y: height - 1}
+	}
+
 	if choice == 1 {
 		entryPoint = getValidBoundaryPoint("начальную", width, height)
 		exitPoint = getValidBoundaryPoint("конечную", width, height)
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/abakunov/mazes/internal/domain"
 	"github.com/abakunov/mazes/internal/infrastructure"
 )
 
@@ -164,8 +165,8 @@ func TestGetAlgorithmChoice_InvalidStringInput(t *testing.T) {
 		}
 	})
 
-	if !strings.Contains(output, "Ошибка: выберите 1 (DFS) или 2 (Kruskal)") {
-		t.Error("Expected output to contain 'Ошибка: выберите 1 (DFS) или 2 (Kruskal)'")
+	if !strings.Contains(output, "Ошибка: выберите 1 (DFS), 2 (Kruskal) или 3 (параллельный)") {
+		t.Error("Expected output to contain 'Ошибка: выберите 1 (DFS), 2 (Kruskal) или 3 (параллельный)'")
 	}
 }
 
@@ -182,8 +183,26 @@ func TestGetAlgorithmChoice_EmptyInput(t *testing.T) {
 		}
 	})
 
-	if !strings.Contains(output, "Ошибка: выберите 1 (DFS) или 2 (Kruskal)") {
-		t.Error("Expected output to contain 'Ошибка: выберите 1 (DFS) или 2 (Kruskal)'")
+	if !strings.Contains(output, "Ошибка: выберите 1 (DFS), 2 (Kruskal) или 3 (параллельный)") {
+		t.Error("Expected output to contain 'Ошибка: выберите 1 (DFS), 2 (Kruskal) или 3 (параллельный)'")
+	}
+}
+
+func TestGetWorkerCount_InvalidNegativeInput(t *testing.T) {
+	mockInput := "-2\n0\n" // -2 - invalid value, then 0 - one worker per CPU
+	restoreStdin := mockStdin(mockInput)
+
+	defer restoreStdin()
+
+	output := captureStdout(func() {
+		workers := infrastructure.GetWorkerCount()
+		if workers != 0 {
+			t.Errorf("Expected worker count to be 0, got %d", workers)
+		}
+	})
+
+	if !strings.Contains(output, "Ошибка: введите неотрицательное число.") {
+		t.Error("Expected output to contain 'Ошибка: введите неотрицательное число.'")
 	}
 }
 
@@ -202,8 +221,35 @@ func TestGetEntryExitChoice_InvalidNegativeInput(t *testing.T) {
 		}
 	})
 
-	if !strings.Contains(output, "Ошибка: выберите 1 (вручную) или 2 (случайным образом)") {
-		t.Error("Expected output to contain 'Ошибка: выберите 1 (вручную) или 2 (случайным образом)'")
+	expected := "Ошибка: выберите 1 (вручную), 2 (случайным образом), 3 (самый длинный путь) или 4 (заданная сложность)"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected output to contain '%s'", expected)
+	}
+}
+
+func TestGetTargetDifficulty_OutOfRangeInput(t *testing.T) {
+	mockInput := "150\n40\n" // 150 - out of range, then 40 - valid value
+	restoreStdin := mockStdin(mockInput)
+
+	defer restoreStdin()
+
+	output := captureStdout(func() {
+		target := infrastructure.GetTargetDifficulty()
+		if target != 40 {
+			t.Errorf("Expected target difficulty to be 40, got %d", target)
+		}
+	})
+
+	if !strings.Contains(output, "Ошибка: введите число от 0 до 100.") {
+		t.Error("Expected output to contain 'Ошибка: введите число от 0 до 100.'")
+	}
+}
+
+func TestGetEntryExitPoints_PlacedAfterGeneration(t *testing.T) {
+	entry, exit := infrastructure.GetEntryExitPoints(3, 21, 15)
+
+	if entry != (domain.Point{X: 1, Y: 0}) || exit != (domain.Point{X: 19, Y: 14}) {
+		t.Errorf("Expected openings (1, 0) and (19, 14), got %v and %v", entry, exit)
 	}
 }
 
@@ -244,3 +290,23 @@ func TestGetPathSolverChoice_EmptyInput(t *testing.T) {
 		t.Error("Expected output to contain 'Ошибка: выберите 1 (BFS) или 2 (A*)'")
 	}
 }
+
+// Tests for the generator comparison choice
+
+func TestGetComparisonChoice_OutOfRangeInput(t *testing.T) {
+	mockInput := "3\n2\n" // 3 - out of range, then 2 - valid value
+	restoreStdin := mockStdin(mockInput)
+
+	defer restoreStdin()
+
+	output := captureStdout(func() {
+		choice := infrastructure.GetComparisonChoice()
+		if choice != 2 {
+			t.Errorf("Expected comparison choice to be 2, got %d", choice)
+		}
+	})
+
+	if !strings.Contains(output, "Ошибка: выберите 1 (да) или 2 (нет).") {
+		t.Error("Expected output to contain 'Ошибка: выберите 1 (да) или 2 (нет).'")
+	}
+}
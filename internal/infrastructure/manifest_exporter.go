@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/abakunov/mazes/internal/analysis"
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// ManifestEntry describes one maze file written by a batch run.
+type ManifestEntry struct {
+	File       string
+	Index      int
+	Seed       int64
+	Generator  string
+	Width      int
+	Height     int
+	Entry      domain.Point
+	Exit       domain.Point
+	PathLength int
+	Metrics    analysis.Metrics
+}
+
+// ManifestExporter writes the list of mazes produced by a batch run as JSON.
+type ManifestExporter struct{}
+
+// NewManifestExporter initializes the ManifestExporter.
+func NewManifestExporter() *ManifestExporter {
+	return &ManifestExporter{}
+}
+
+// Export writes the entries ordered by their index, along with how many mazes were generated in total.
+func (e *ManifestExporter) Export(w io.Writer, generated int, entries []ManifestEntry) error {
+	type jsonPoint struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	type jsonMetrics struct {
+		Passages        int     `json:"passages"`
+		DeadEnds        int     `json:"dead_ends"`
+		Junctions       int     `json:"junctions"`
+		BranchingFactor float64 `json:"branching_factor"`
+		SolutionRatio   float64 `json:"solution_ratio"`
+		Decisions       int     `json:"decisions"`
+		Turns           int     `json:"turns"`
+		River           float64 `json:"river"`
+		LongestCorridor int     `json:"longest_corridor"`
+		Difficulty      float64 `json:"difficulty"`
+	}
+
+	type jsonEntry struct {
+		File       string      `json:"file"`
+		Index      int         `json:"index"`
+		Seed       int64       `json:"seed"`
+		Generator  string      `json:"generator"`
+		Width      int         `json:"width"`
+		Height     int         `json:"height"`
+		Entry      jsonPoint   `json:"entry"`
+		Exit       jsonPoint   `json:"exit"`
+		PathLength int         `json:"path_length"`
+		Metrics    jsonMetrics `json:"metrics"`
+	}
+
+	type jsonManifest struct {
+		Generated int         `json:"generated"`
+		Accepted  int         `json:"accepted"`
+		Mazes     []jsonEntry `json:"mazes"`
+	}
+
+	sorted := append([]ManifestEntry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	manifest := jsonManifest{Generated: generated, Accepted: len(sorted), Mazes: make([]jsonEntry, 0, len(sorted))}
+
+	for _, entry := range sorted {
+		manifest.Mazes = append(manifest.Mazes, jsonEntry{
+			File:       entry.File,
+			Index:      entry.Index,
+			Seed:       entry.Seed,
+			Generator:  entry.Generator,
+			Width:      entry.Width,
+			Height:     entry.Height,
+			Entry:      jsonPoint{X: entry.Entry.X, Y: entry.Entry.Y},
+			Exit:       jsonPoint{X: entry.Exit.X, Y: entry.Exit.Y},
+			PathLength: entry.PathLength,
+			Metrics: jsonMetrics{
+				Passages:        entry.Metrics.Passages,
+				DeadEnds:        entry.Metrics.DeadEnds,
+				Junctions:       entry.Metrics.Junctions,
+				BranchingFactor: entry.Metrics.BranchingFactor,
+				SolutionRatio:   entry.Metrics.SolutionRatio,
+				Decisions:       entry.Metrics.Decisions,
+				Turns:           entry.Metrics.Turns,
+				River:           entry.Metrics.River,
+				LongestCorridor: entry.Metrics.LongestCorridor,
+				Difficulty:      entry.Metrics.Difficulty,
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(manifest)
+}
@@ -0,0 +1,68 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/analysis"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+func TestManifestExporter_Export(t *testing.T) {
+	entries := []infrastructure.ManifestEntry{
+		{File: "maze-00002.maze", Index: 2, Seed: 9, Generator: "dfs", Width: 21, Height: 21, PathLength: 40},
+		{File: "maze-00000.maze", Index: 0, Seed: 7, Generator: "kruskal", Width: 11, Height: 11,
+			Entry: domain.Point{X: 1, Y: 0}, Exit: domain.Point{X: 9, Y: 10}, PathLength: 25,
+			Metrics: analysis.Metrics{DeadEnds: 5, Junctions: 3, River: 2.5, Difficulty: 41.5}},
+	}
+
+	var buf bytes.Buffer
+	if err := infrastructure.NewManifestExporter().Export(&buf, 3, entries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var manifest struct {
+		Generated int `json:"generated"`
+		Accepted  int `json:"accepted"`
+		Mazes     []struct {
+			File       string         `json:"file"`
+			Index      int            `json:"index"`
+			Seed       int64          `json:"seed"`
+			Generator  string         `json:"generator"`
+			Exit       map[string]int `json:"exit"`
+			PathLength int            `json:"path_length"`
+			Metrics    struct {
+				DeadEnds   int     `json:"dead_ends"`
+				River      float64 `json:"river"`
+				Difficulty float64 `json:"difficulty"`
+			} `json:"metrics"`
+		} `json:"mazes"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &manifest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if manifest.Generated != 3 || manifest.Accepted != 2 {
+		t.Errorf("Expected 3 generated and 2 accepted mazes, got %d and %d", manifest.Generated, manifest.Accepted)
+	}
+
+	if len(manifest.Mazes) != 2 || manifest.Mazes[0].Index != 0 || manifest.Mazes[1].Index != 2 {
+		t.Fatalf("Expected mazes ordered by index, got %+v", manifest.Mazes)
+	}
+
+	first := manifest.Mazes[0]
+	if first.File != "maze-00000.maze" || first.Seed != 7 || first.Generator != "kruskal" || first.PathLength != 25 {
+		t.Errorf("Unexpected manifest entry %+v", first)
+	}
+
+	if first.Metrics.DeadEnds != 5 || first.Metrics.River != 2.5 || first.Metrics.Difficulty != 41.5 {
+		t.Errorf("Expected the metrics to be written, got %+v", first.Metrics)
+	}
+
+	if first.Exit["x"] != 9 || first.Exit["y"] != 10 {
+		t.Errorf("Expected exit (9, 10), got %v", first.Exit)
+	}
+}
@@ -0,0 +1,249 @@
+package infrastructure
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// Page geometry of the puzzle book (A4 in PDF points).
+const (
+	pdfPageWidth    = 595.0
+	pdfPageHeight   = 842.0
+	pdfPageMargin   = 40.0
+	pdfHeaderHeight = 30.0
+	pdfLabelHeight  = 18.0
+	pdfSlotPadding  = 12.0
+)
+
+// ErrNoPuzzles is returned when the puzzle book has nothing to export.
+var ErrNoPuzzles = errors.New("puzzle book has no mazes")
+
+// PuzzleBookEntry describes a single maze of the puzzle book.
+type PuzzleBookEntry struct {
+	Title      string
+	Difficulty string
+	Maze       *domain.Maze
+	Solution   []domain.Point
+}
+
+// PDFExporter writes printable puzzle books as multi-page PDF documents.
+type PDFExporter struct {
+	Title        string
+	MazesPerPage int
+}
+
+// NewPDFExporter initializes the PDFExporter.
+func NewPDFExporter(title string, mazesPerPage int) *PDFExporter {
+	return &PDFExporter{Title: title, MazesPerPage: mazesPerPage}
+}
+
+// Export writes the puzzle pages followed by the answer key with the solutions drawn in.
+func (e *PDFExporter) Export(w io.Writer, entries []PuzzleBookEntry) error {
+	if len(entries) == 0 {
+		return ErrNoPuzzles
+	}
+
+	perPage := e.MazesPerPage
+	if perPage < 1 {
+		perPage = 1
+	}
+
+	doc := newPDFDocument()
+
+	// Puzzle pages
+	for start := 0; start < len(entries); start += perPage {
+		end := min(start+perPage, len(entries))
+		doc.addPage(e.renderPage(e.Title, entries[start:end], start, perPage, false))
+	}
+
+	// Answer key pages
+	for start := 0; start < len(entries); start += perPage {
+		end := min(start+perPage, len(entries))
+		doc.addPage(e.renderPage("Answer key", entries[start:end], start, perPage, true))
+	}
+
+	_, err := doc.WriteTo(w)
+
+	return err
+}
+
+// renderPage builds the content stream of a single page.
+func (e *PDFExporter) renderPage(header string, entries []PuzzleBookEntry, offset, perPage int, withSolution bool) []byte {
+	var content bytes.Buffer
+
+	// Page header
+	writePDFText(&content, pdfPageMargin, pdfPageHeight-pdfPageMargin-16, 16, header)
+
+	cols := int(math.Ceil(math.Sqrt(float64(perPage))))
+	rows := (perPage + cols - 1) / cols
+
+	areaWidth := pdfPageWidth - 2*pdfPageMargin
+	areaHeight := pdfPageHeight - 2*pdfPageMargin - pdfHeaderHeight
+	slotWidth := areaWidth / float64(cols)
+	slotHeight := areaHeight / float64(rows)
+
+	for i, entry := range entries {
+		col := i % cols
+		row := i / cols
+
+		// Top-left corner of the slot in PDF coordinates
+		slotX := pdfPageMargin + float64(col)*slotWidth
+		slotTop := pdfPageHeight - pdfPageMargin - pdfHeaderHeight - float64(row)*slotHeight
+
+		label := fmt.Sprintf("%d. %s", offset+i+1, entry.Title)
+		if entry.Difficulty != "" {
+			label += " (" + entry.Difficulty + ")"
+		}
+
+		writePDFText(&content, slotX+pdfSlotPadding/2, slotTop-12, 10, label)
+
+		if entry.Maze == nil || entry.Maze.Width == 0 || entry.Maze.Height == 0 {
+			continue
+		}
+
+		// Fit the maze into the slot keeping square cells
+		boxWidth := slotWidth - pdfSlotPadding
+		boxHeight := slotHeight - pdfSlotPadding - pdfLabelHeight
+		cellSize := math.Min(boxWidth/float64(entry.Maze.Width), boxHeight/float64(entry.Maze.Height))
+		originX := slotX + (slotWidth-cellSize*float64(entry.Maze.Width))/2
+		originTop := slotTop - pdfLabelHeight
+
+		if withSolution {
+			writePDFSolution(&content, entry.Solution, originX, originTop, cellSize)
+		}
+
+		writePDFWalls(&content, entry.Maze, originX, originTop, cellSize)
+	}
+
+	return content.Bytes()
+}
+
+// writePDFWalls draws the walls of the maze, merging horizontal runs into single rectangles.
+func writePDFWalls(content *bytes.Buffer, maze *domain.Maze, originX, originTop, cellSize float64) {
+	content.WriteString("0 0 0 rg\n")
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if !maze.Grid[y][x].Wall {
+				continue
+			}
+
+			runStart := x
+			for x+1 < maze.Width && maze.Grid[y][x+1].Wall {
+				x++
+			}
+
+			fmt.Fprintf(content, "%.2f %.2f %.2f %.2f re\n",
+				originX+float64(runStart)*cellSize, originTop-float64(y+1)*cellSize,
+				float64(x-runStart+1)*cellSize, cellSize)
+		}
+	}
+
+	content.WriteString("f\n")
+}
+
+// writePDFSolution fills the cells of the solution path.
+func writePDFSolution(content *bytes.Buffer, path []domain.Point, originX, originTop, cellSize float64) {
+	if len(path) == 0 {
+		return
+	}
+
+	content.WriteString("0.30 0.75 0.35 rg\n")
+
+	for _, p := range path {
+		fmt.Fprintf(content, "%.2f %.2f %.2f %.2f re\n",
+			originX+float64(p.X)*cellSize, originTop-float64(p.Y+1)*cellSize, cellSize, cellSize)
+	}
+
+	content.WriteString("f\n")
+}
+
+// writePDFText places a single line of text using the built-in Helvetica font.
+func writePDFText(content *bytes.Buffer, x, y, size float64, text string) {
+	fmt.Fprintf(content, "0 0 0 rg\nBT /F1 %.0f Tf %.2f %.2f Td (%s) Tj ET\n", size, x, y, escapePDFString(text))
+}
+
+// escapePDFString escapes a string literal, replacing characters outside of printable ASCII.
+func escapePDFString(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// pdfDocument is a minimal PDF 1.4 writer supporting pages with content streams.
+type pdfDocument struct {
+	pages [][]byte
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{}
+}
+
+// addPage appends a page with the given content stream.
+func (d *pdfDocument) addPage(content []byte) {
+	d.pages = append(d.pages, content)
+}
+
+// WriteTo serializes the document with a cross-reference table.
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	// Object numbers: 1 - catalog, 2 - page tree, 3 - font, then a page and its content per page
+	objectCount := 3 + 2*len(d.pages)
+	offsets := make([]int, objectCount+1)
+
+	writeObject := func(num int, body string) {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", num, body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	writeObject(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+
+	writeObject(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+
+	for i, content := range d.pages {
+		pageNum := 4 + 2*i
+		writeObject(pageNum, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, pageNum+1))
+		writeObject(pageNum+1, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	// Cross-reference table
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", objectCount+1)
+
+	for num := 1; num <= objectCount; num++ {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offsets[num])
+	}
+
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", objectCount+1, xrefOffset)
+
+	return buf.WriteTo(w)
+}
@@ -0,0 +1,77 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+func TestPDFExporter_Export_PagesAndAnswerKey(t *testing.T) {
+	entry := domain.Point{X: 1, Y: 0}
+	exit := domain.Point{X: 9, Y: 10}
+
+	var entries []infrastructure.PuzzleBookEntry
+
+	for i := 0; i < 3; i++ {
+		maze := domain.NewMaze(11, 11)
+		if err := (&application.DFSGenerator{}).Generate(maze, entry, exit); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		solution, err := (&application.BFSSolver{}).FindPath(maze, entry, exit)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		entries = append(entries, infrastructure.PuzzleBookEntry{
+			Title:      "Maze (easy)",
+			Difficulty: "Easy",
+			Maze:       maze,
+			Solution:   solution,
+		})
+	}
+
+	var buf bytes.Buffer
+
+	exporter := infrastructure.NewPDFExporter("Puzzle book", 2)
+	if err := exporter.Export(&buf, entries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+
+	if !strings.HasPrefix(output, "%PDF-1.4") {
+		t.Error("Expected output to start with the PDF header")
+	}
+
+	if !strings.HasSuffix(output, "%%EOF\n") {
+		t.Error("Expected output to end with the EOF marker")
+	}
+
+	// 3 mazes with 2 per page: 2 puzzle pages and 2 answer key pages
+	if !strings.Contains(output, "/Count 4") {
+		t.Error("Expected the document to contain 4 pages")
+	}
+
+	if !strings.Contains(output, "(Answer key)") {
+		t.Error("Expected the document to contain the answer key section")
+	}
+
+	if !strings.Contains(output, `(1. Maze \(easy\) \(Easy\))`) {
+		t.Error("Expected titles to be escaped and labeled with difficulty")
+	}
+}
+
+func TestPDFExporter_Export_NoEntries(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := infrastructure.NewPDFExporter("Puzzle book", 4).Export(&buf, nil)
+	if !errors.Is(err, infrastructure.ErrNoPuzzles) {
+		t.Errorf("Expected ErrNoPuzzles, got %v", err)
+	}
+}
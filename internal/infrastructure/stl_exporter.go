@@ -0,0 +1,157 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+// ErrInvalidSTLDimensions is returned when the cell size, wall height or base thickness is not positive.
+var ErrInvalidSTLDimensions = errors.New("cell size, wall height and base thickness must be positive")
+
+// STLExporter extrudes maze walls into a watertight binary STL mesh standing on a solid base.
+// All dimensions are in millimeters.
+type STLExporter struct {
+	CellSize      float64
+	WallHeight    float64
+	BaseThickness float64
+}
+
+// NewSTLExporter initializes the STLExporter with 10 mm cells, 10 mm walls and a 2 mm base.
+func NewSTLExporter() *STLExporter {
+	return &STLExporter{CellSize: 10, WallHeight: 10, BaseThickness: 2}
+}
+
+type stlVertex [3]float32
+
+type stlTriangle struct {
+	normal   stlVertex
+	vertices [3]stlVertex
+}
+
+// Export writes the mesh of the maze in the binary STL format.
+func (e *STLExporter) Export(w io.Writer, maze *domain.Maze) error {
+	if e.CellSize <= 0 || e.WallHeight <= 0 || e.BaseThickness <= 0 {
+		return ErrInvalidSTLDimensions
+	}
+
+	triangles := e.buildMesh(maze)
+
+	bw := bufio.NewWriter(w)
+
+	// 80-byte header followed by the triangle count
+	header := make([]byte, 80)
+	copy(header, "maze")
+
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+
+	if err := binary.Write(bw, binary.LittleEndian, uint32(len(triangles))); err != nil {
+		return err
+	}
+
+	for _, t := range triangles {
+		if err := binary.Write(bw, binary.LittleEndian, t.normal); err != nil {
+			return err
+		}
+
+		if err := binary.Write(bw, binary.LittleEndian, t.vertices); err != nil {
+			return err
+		}
+
+		// Attribute byte count
+		if err := binary.Write(bw, binary.LittleEndian, uint16(0)); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// buildMesh treats the maze as a heightfield: every cell is a column of base height (floor)
+// or base plus wall height (wall). Side faces are split at the base level so that all
+// neighboring faces share whole edges and the mesh stays watertight.
+func (e *STLExporter) buildMesh(maze *domain.Maze) []stlTriangle {
+	var triangles []stlTriangle
+
+	height := func(x, y int) float64 {
+		if x < 0 || x >= maze.Width || y < 0 || y >= maze.Height {
+			return 0
+		}
+
+		if maze.Grid[y][x].Wall {
+			return e.BaseThickness + e.WallHeight
+		}
+
+		return e.BaseThickness
+	}
+
+	addQuad := func(a, b, c, d stlVertex) {
+		triangles = append(triangles, newSTLTriangle(a, b, c), newSTLTriangle(a, c, d))
+	}
+
+	// addSide adds a vertical face from lo to hi, split at the base level.
+	addSide := func(lo, hi float64, face func(zlo, zhi float32)) {
+		if lo < e.BaseThickness && e.BaseThickness < hi {
+			face(float32(lo), float32(e.BaseThickness))
+			lo = e.BaseThickness
+		}
+
+		if lo < hi {
+			face(float32(lo), float32(hi))
+		}
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			// Row 0 is at the back so the print is not mirrored
+			x0 := float32(float64(x) * e.CellSize)
+			x1 := float32(float64(x+1) * e.CellSize)
+			y0 := float32(float64(maze.Height-1-y) * e.CellSize)
+			y1 := float32(float64(maze.Height-y) * e.CellSize)
+
+			h := height(x, y)
+			z := float32(h)
+
+			// Top and bottom
+			addQuad(stlVertex{x0, y0, z}, stlVertex{x1, y0, z}, stlVertex{x1, y1, z}, stlVertex{x0, y1, z})
+			addQuad(stlVertex{x0, y1, 0}, stlVertex{x1, y1, 0}, stlVertex{x1, y0, 0}, stlVertex{x0, y0, 0})
+
+			// Sides facing lower neighbors or the outside
+			addSide(height(x+1, y), h, func(zlo, zhi float32) {
+				addQuad(stlVertex{x1, y0, zlo}, stlVertex{x1, y1, zlo}, stlVertex{x1, y1, zhi}, stlVertex{x1, y0, zhi})
+			})
+			addSide(height(x-1, y), h, func(zlo, zhi float32) {
+				addQuad(stlVertex{x0, y1, zlo}, stlVertex{x0, y0, zlo}, stlVertex{x0, y0, zhi}, stlVertex{x0, y1, zhi})
+			})
+			addSide(height(x, y-1), h, func(zlo, zhi float32) {
+				addQuad(stlVertex{x1, y1, zlo}, stlVertex{x0, y1, zlo}, stlVertex{x0, y1, zhi}, stlVertex{x1, y1, zhi})
+			})
+			addSide(height(x, y+1), h, func(zlo, zhi float32) {
+				addQuad(stlVertex{x0, y0, zlo}, stlVertex{x1, y0, zlo}, stlVertex{x1, y0, zhi}, stlVertex{x0, y0, zhi})
+			})
+		}
+	}
+
+	return triangles
+}
+
+// newSTLTriangle creates a triangle with counter-clockwise vertices and computes its unit normal.
+func newSTLTriangle(a, b, c stlVertex) stlTriangle {
+	ux, uy, uz := b[0]-a[0], b[1]-a[1], b[2]-a[2]
+	vx, vy, vz := c[0]-a[0], c[1]-a[1], c[2]-a[2]
+
+	n := stlVertex{uy*vz - uz*vy, uz*vx - ux*vz, ux*vy - uy*vx}
+
+	length := float32(math.Sqrt(float64(n[0]*n[0] + n[1]*n[1] + n[2]*n[2])))
+	if length > 0 {
+		n = stlVertex{n[0] / length, n[1] / length, n[2] / length}
+	}
+
+	return stlTriangle{normal: n, vertices: [3]stlVertex{a, b, c}}
+}
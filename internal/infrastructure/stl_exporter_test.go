@@ -0,0 +1,73 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/application"
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+func TestSTLExporter_Export_Watertight(t *testing.T) {
+	maze := domain.NewMaze(11, 11)
+	if err := (&application.KruskalGenerator{}).Generate(maze, domain.Point{X: 1, Y: 0}, domain.Point{X: 9, Y: 10}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := infrastructure.NewSTLExporter().Export(&buf, maze); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data := buf.Bytes()
+	count := binary.LittleEndian.Uint32(data[80:84])
+
+	if len(data) != 84+int(count)*50 {
+		t.Fatalf("Expected %d bytes for %d triangles, got %d", 84+int(count)*50, count, len(data))
+	}
+
+	type vertex [3]float32
+
+	type edge struct{ from, to vertex }
+
+	// In a closed, consistently oriented mesh every directed edge is matched by its reverse
+	edges := make(map[edge]int)
+
+	for i := 0; i < int(count); i++ {
+		var triangle struct {
+			Normal   vertex
+			Vertices [3]vertex
+			Attr     uint16
+		}
+
+		offset := 84 + i*50
+		if err := binary.Read(bytes.NewReader(data[offset:offset+50]), binary.LittleEndian, &triangle); err != nil {
+			t.Fatalf("Failed to read triangle %d: %v", i, err)
+		}
+
+		for j := 0; j < 3; j++ {
+			edges[edge{triangle.Vertices[j], triangle.Vertices[(j+1)%3]}]++
+		}
+	}
+
+	for e, n := range edges {
+		if edges[edge{e.to, e.from}] != n {
+			t.Fatalf("Mesh is not watertight at edge %v -> %v", e.from, e.to)
+		}
+	}
+}
+
+func TestSTLExporter_Export_InvalidDimensions(t *testing.T) {
+	exporter := infrastructure.NewSTLExporter()
+	exporter.BaseThickness = 0
+
+	var buf bytes.Buffer
+
+	err := exporter.Export(&buf, domain.NewMaze(3, 3))
+	if !errors.Is(err, infrastructure.ErrInvalidSTLDimensions) {
+		t.Errorf("Expected ErrInvalidSTLDimensions, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/abakunov/mazes/internal/domain"
+)
+
+const tiledVersion = "1.10"
+
+// TiledExporter writes mazes as Tiled maps with a tile layer for walls and floor
+// and an object layer with the entry, exit and optional solution waypoints.
+type TiledExporter struct {
+	TileWidth  int
+	TileHeight int
+	// FloorTileID and WallTileID are tile IDs local to the tileset; the map stores them as
+	// global IDs, gid = id + FirstGID.
+	FloorTileID int
+	WallTileID  int
+	// FirstGID is the global ID of the first tile of the tileset; 1 is used if zero.
+	FirstGID      int
+	TilesetSource string
+}
+
+// NewTiledExporter initializes the TiledExporter with 32x32 tiles, floor tile 0 and wall tile 1
+// of a tileset starting at global ID 1.
+func NewTiledExporter() *TiledExporter {
+	return &TiledExporter{
+		TileWidth:     32,
+		TileHeight:    32,
+		FloorTileID:   0,
+		WallTileID:    1,
+		FirstGID:      1,
+		TilesetSource: "maze.tsx",
+	}
+}
+
+// tiledObject is a marker placed on the object layer.
+type tiledObject struct {
+	ID       int
+	Name     string
+	X        float64
+	Y        float64
+	Polyline []domain.Point
+}
+
+type jsonPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type jsonObject struct {
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	X        float64     `json:"x"`
+	Y        float64     `json:"y"`
+	Width    float64     `json:"width"`
+	Height   float64     `json:"height"`
+	Rotation float64     `json:"rotation"`
+	Visible  bool        `json:"visible"`
+	Point    bool        `json:"point,omitempty"`
+	Polyline []jsonPoint `json:"polyline,omitempty"`
+}
+
+type jsonLayer struct {
+	ID        int          `json:"id"`
+	Name      string       `json:"name"`
+	Type      string       `json:"type"`
+	Width     int          `json:"width,omitempty"`
+	Height    int          `json:"height,omitempty"`
+	Data      []int        `json:"data,omitempty"`
+	DrawOrder string       `json:"draworder,omitempty"`
+	Objects   []jsonObject `json:"objects,omitempty"`
+	Opacity   float64      `json:"opacity"`
+	Visible   bool         `json:"visible"`
+	X         int          `json:"x"`
+	Y         int          `json:"y"`
+}
+
+type jsonTileset struct {
+	FirstGID int    `json:"firstgid"`
+	Source   string `json:"source"`
+}
+
+type jsonMap struct {
+	Type         string        `json:"type"`
+	Version      string        `json:"version"`
+	Orientation  string        `json:"orientation"`
+	RenderOrder  string        `json:"renderorder"`
+	Width        int           `json:"width"`
+	Height       int           `json:"height"`
+	TileWidth    int           `json:"tilewidth"`
+	TileHeight   int           `json:"tileheight"`
+	Infinite     bool          `json:"infinite"`
+	NextLayerID  int           `json:"nextlayerid"`
+	NextObjectID int           `json:"nextobjectid"`
+	Layers       []jsonLayer   `json:"layers"`
+	Tilesets     []jsonTileset `json:"tilesets"`
+}
+
+type tmxPolyline struct {
+	Points string `xml:"points,attr"`
+}
+
+type tmxObject struct {
+	ID       int          `xml:"id,attr"`
+	Name     string       `xml:"name,attr"`
+	Type     string       `xml:"type,attr"`
+	X        float64      `xml:"x,attr"`
+	Y        float64      `xml:"y,attr"`
+	Point    *struct{}    `xml:"point"`
+	Polyline *tmxPolyline `xml:"polyline"`
+}
+
+type tmxData struct {
+	Encoding string `xml:"encoding,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type tmxLayer struct {
+	ID     int     `xml:"id,attr"`
+	Name   string  `xml:"name,attr"`
+	Width  int     `xml:"width,attr"`
+	Height int     `xml:"height,attr"`
+	Data   tmxData `xml:"data"`
+}
+
+type tmxObjectGroup struct {
+	ID      int         `xml:"id,attr"`
+	Name    string      `xml:"name,attr"`
+	Objects []tmxObject `xml:"object"`
+}
+
+type tmxTileset struct {
+	FirstGID int    `xml:"firstgid,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+type tmxMap struct {
+	XMLName      xml.Name       `xml:"map"`
+	Version      string         `xml:"version,attr"`
+	Orientation  string         `xml:"orientation,attr"`
+	RenderOrder  string         `xml:"renderorder,attr"`
+	Width        int            `xml:"width,attr"`
+	Height       int            `xml:"height,attr"`
+	TileWidth    int            `xml:"tilewidth,attr"`
+	TileHeight   int            `xml:"tileheight,attr"`
+	Infinite     int            `xml:"infinite,attr"`
+	NextLayerID  int            `xml:"nextlayerid,attr"`
+	NextObjectID int            `xml:"nextobjectid,attr"`
+	Tileset      tmxTileset     `xml:"tileset"`
+	Layer        tmxLayer       `xml:"layer"`
+	ObjectGroup  tmxObjectGroup `xml:"objectgroup"`
+}
+
+// ExportJSON writes the maze in the Tiled JSON map format.
+func (e *TiledExporter) ExportJSON(w io.Writer, maze *domain.Maze, entry, exit domain.Point, solution []domain.Point) error {
+	objects := e.buildObjects(entry, exit, solution)
+
+	m := jsonMap{
+		Type:         "map",
+		Version:      tiledVersion,
+		Orientation:  "orthogonal",
+		RenderOrder:  "right-down",
+		Width:        maze.Width,
+		Height:       maze.Height,
+		TileWidth:    e.TileWidth,
+		TileHeight:   e.TileHeight,
+		NextLayerID:  3,
+		NextObjectID: len(objects) + 1,
+		Layers: []jsonLayer{
+			{ID: 1, Name: "maze", Type: "tilelayer", Width: maze.Width, Height: maze.Height,
+				Data: e.tileData(maze), Opacity: 1, Visible: true},
+			{ID: 2, Name: "markers", Type: "objectgroup", DrawOrder: "topdown",
+				Objects: e.jsonObjects(objects), Opacity: 1, Visible: true},
+		},
+		Tilesets: []jsonTileset{{FirstGID: e.firstGID(), Source: e.TilesetSource}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(m)
+}
+
+// jsonObjects converts the markers to objects of the JSON object layer.
+func (e *TiledExporter) jsonObjects(objects []tiledObject) []jsonObject {
+	jsonObjects := make([]jsonObject, 0, len(objects))
+
+	for _, obj := range objects {
+		jo := jsonObject{ID: obj.ID, Name: obj.Name, Type: obj.Name, X: obj.X, Y: obj.Y, Visible: true}
+
+		if obj.Polyline == nil {
+			jo.Point = true
+		}
+
+		for _, p := range obj.Polyline {
+			jo.Polyline = append(jo.Polyline, jsonPoint{X: float64(p.X), Y: float64(p.Y)})
+		}
+
+		jsonObjects = append(jsonObjects, jo)
+	}
+
+	return jsonObjects
+}
+
+// ExportTMX writes the maze in the Tiled XML (TMX) map format.
+func (e *TiledExporter) ExportTMX(w io.Writer, maze *domain.Maze, entry, exit domain.Point, solution []domain.Point) error {
+	objects := e.buildObjects(entry, exit, solution)
+
+	m := tmxMap{
+		Version:      tiledVersion,
+		Orientation:  "orthogonal",
+		RenderOrder:  "right-down",
+		Width:        maze.Width,
+		Height:       maze.Height,
+		TileWidth:    e.TileWidth,
+		TileHeight:   e.TileHeight,
+		NextLayerID:  3,
+		NextObjectID: len(objects) + 1,
+		Tileset:      tmxTileset{FirstGID: e.firstGID(), Source: e.TilesetSource},
+		Layer: tmxLayer{ID: 1, Name: "maze", Width: maze.Width, Height: maze.Height,
+			Data: tmxData{Encoding: "csv", Value: e.csvData(maze)}},
+		ObjectGroup: tmxObjectGroup{ID: 2, Name: "markers", Objects: e.tmxObjects(objects)},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", " ")
+
+	if err := encoder.Encode(m); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// tmxObjects converts the markers to objects of the TMX object group.
+func (e *TiledExporter) tmxObjects(objects []tiledObject) []tmxObject {
+	tmxObjects := make([]tmxObject, 0, len(objects))
+
+	for _, obj := range objects {
+		to := tmxObject{ID: obj.ID, Name: obj.Name, Type: obj.Name, X: obj.X, Y: obj.Y}
+
+		if obj.Polyline == nil {
+			to.Point = &struct{}{}
+		} else {
+			points := make([]string, len(obj.Polyline))
+			for i, p := range obj.Polyline {
+				points[i] = fmt.Sprintf("%d,%d", p.X, p.Y)
+			}
+
+			to.Polyline = &tmxPolyline{Points: strings.Join(points, " ")}
+		}
+
+		tmxObjects = append(tmxObjects, to)
+	}
+
+	return tmxObjects
+}
+
+// csvData returns the global tile IDs of the maze as CSV, one map row per line.
+func (e *TiledExporter) csvData(maze *domain.Maze) string {
+	data := e.tileData(maze)
+	rows := make([]string, maze.Height)
+
+	for y := 0; y < maze.Height; y++ {
+		cells := make([]string, maze.Width)
+		for x := 0; x < maze.Width; x++ {
+			cells[x] = strconv.Itoa(data[y*maze.Width+x])
+		}
+
+		rows[y] = strings.Join(cells, ",")
+	}
+
+	return "\n" + strings.Join(rows, ",\n") + "\n"
+}
+
+// tileData returns the global tile IDs of the maze in row-major order.
+func (e *TiledExporter) tileData(maze *domain.Maze) []int {
+	data := make([]int, 0, maze.Width*maze.Height)
+	floor, wall := e.FloorTileID+e.firstGID(), e.WallTileID+e.firstGID()
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if maze.Grid[y][x].Wall {
+				data = append(data, wall)
+			} else {
+				data = append(data, floor)
+			}
+		}
+	}
+
+	return data
+}
+
+// buildObjects places the entry and exit markers at tile centers and turns the solution
+// into a polyline through its waypoints (the path ends and every turn).
+func (e *TiledExporter) buildObjects(entry, exit domain.Point, solution []domain.Point) []tiledObject {
+	entryX, entryY := e.tileCenter(entry)
+	exitX, exitY := e.tileCenter(exit)
+
+	objects := []tiledObject{
+		{ID: 1, Name: "entry", X: entryX, Y: entryY},
+		{ID: 2, Name: "exit", X: exitX, Y: exitY},
+	}
+
+	if len(solution) < 2 {
+		return objects
+	}
+
+	startX, startY := e.tileCenter(solution[0])
+	polyline := []domain.Point{{X: 0, Y: 0}}
+
+	for i := 1; i < len(solution); i++ {
+		isLast := i == len(solution)-1
+		if !isLast && solution[i].X-solution[i-1].X == solution[i+1].X-solution[i].X &&
+			solution[i].Y-solution[i-1].Y == solution[i+1].Y-solution[i].Y {
+			continue // Not a turn
+		}
+
+		// Polyline points are relative to the object position
+		polyline = append(polyline, domain.Point{
+			X: (solution[i].X - solution[0].X) * e.TileWidth,
+			Y: (solution[i].Y - solution[0].Y) * e.TileHeight,
+		})
+	}
+
+	return append(objects, tiledObject{ID: 3, Name: "solution", X: startX, Y: startY, Polyline: polyline})
+}
+
+// tileCenter returns the pixel coordinates of the center of the tile.
+func (e *TiledExporter) tileCenter(p domain.Point) (x, y float64) {
+	return (float64(p.X) + 0.5) * float64(e.TileWidth), (float64(p.Y) + 0.5) * float64(e.TileHeight)
+}
+
+func (e *TiledExporter) firstGID() int {
+	if e.FirstGID > 0 {
+		return e.FirstGID
+	}
+
+	return 1
+}
@@ -0,0 +1,130 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/abakunov/mazes/internal/domain"
+	"github.com/abakunov/mazes/internal/infrastructure"
+)
+
+// newTiledTestMaze returns a 3x3 maze with an L-shaped corridor from (0,0) to (2,2).
+func newTiledTestMaze() (maze *domain.Maze, path []domain.Point) {
+	maze = domain.NewMaze(3, 3)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			maze.Grid[y][x].Wall = true
+		}
+	}
+
+	path = []domain.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}}
+	for _, p := range path {
+		maze.Grid[p.Y][p.X].Wall = false
+	}
+
+	return maze, path
+}
+
+func TestTiledExporter_ExportJSON(t *testing.T) {
+	maze, path := newTiledTestMaze()
+
+	exporter := infrastructure.NewTiledExporter()
+	exporter.FloorTileID = 5
+	exporter.WallTileID = 7
+
+	var buf bytes.Buffer
+	if err := exporter.ExportJSON(&buf, maze, path[0], path[len(path)-1], path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Width  int `json:"width"`
+		Layers []struct {
+			Type    string `json:"type"`
+			Data    []int  `json:"data"`
+			Objects []struct {
+				Name     string               `json:"name"`
+				X        float64              `json:"x"`
+				Polyline []map[string]float64 `json:"polyline"`
+			} `json:"objects"`
+		} `json:"layers"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	if decoded.Width != 3 || len(decoded.Layers) != 2 {
+		t.Fatalf("Unexpected map layout: width %d, %d layers", decoded.Width, len(decoded.Layers))
+	}
+
+	// Global IDs are the tileset IDs offset by the first GID of the tileset
+	expectedData := []int{6, 6, 6, 8, 8, 6, 8, 8, 6}
+	for i, id := range expectedData {
+		if decoded.Layers[0].Data[i] != id {
+			t.Errorf("Expected tile %d to be %d, got %d", i, id, decoded.Layers[0].Data[i])
+		}
+	}
+
+	objects := decoded.Layers[1].Objects
+	if len(objects) != 3 || objects[0].Name != "entry" || objects[1].Name != "exit" || objects[2].Name != "solution" {
+		t.Fatalf("Expected entry, exit and solution objects, got %+v", objects)
+	}
+
+	if objects[0].X != 16 {
+		t.Errorf("Expected entry to be centered in its tile, got x=%v", objects[0].X)
+	}
+
+	// Start, the single turn and the end of the path
+	if len(objects[2].Polyline) != 3 {
+		t.Errorf("Expected 3 waypoints, got %d", len(objects[2].Polyline))
+	}
+}
+
+func TestTiledExporter_ExportTMX(t *testing.T) {
+	maze, path := newTiledTestMaze()
+
+	var buf bytes.Buffer
+	exporter := infrastructure.NewTiledExporter()
+	exporter.FirstGID = 10
+
+	if err := exporter.ExportTMX(&buf, maze, path[0], path[len(path)-1], nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Width   int `xml:"width,attr"`
+		Tileset struct {
+			FirstGID int `xml:"firstgid,attr"`
+		} `xml:"tileset"`
+		Layer struct {
+			Data string `xml:"data"`
+		} `xml:"layer"`
+		Objects []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"objectgroup>object"`
+	}
+
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid XML, got %v", err)
+	}
+
+	if decoded.Width != 3 {
+		t.Errorf("Expected width 3, got %d", decoded.Width)
+	}
+
+	if decoded.Tileset.FirstGID != 10 {
+		t.Errorf("Expected first GID 10, got %d", decoded.Tileset.FirstGID)
+	}
+
+	if strings.TrimSpace(decoded.Layer.Data) != "10,10,10,\n11,11,10,\n11,11,10" {
+		t.Errorf("Unexpected CSV tile data: %q", decoded.Layer.Data)
+	}
+
+	if len(decoded.Objects) != 2 {
+		t.Errorf("Expected only entry and exit objects without a solution, got %d", len(decoded.Objects))
+	}
+}